@@ -3,23 +3,51 @@ package main
 import (
 	"github.com/graphzc/wiresetgen/internal/commands"
 	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/repositories/cache"
 	"github.com/graphzc/wiresetgen/internal/repositories/files"
 	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/graphzc/wiresetgen/internal/services/graph"
+	"github.com/graphzc/wiresetgen/internal/services/inject"
+	"github.com/graphzc/wiresetgen/internal/services/migrate"
+	"github.com/graphzc/wiresetgen/internal/services/scaffold"
+	"github.com/graphzc/wiresetgen/internal/services/suggest"
+	"github.com/graphzc/wiresetgen/internal/services/watcher"
 )
 
 func main() {
 	// Initialize repositories
-	fileRepository := files.NewFileRepository()
+	fileRepository := files.NewFileRepository(files.BASE_DIR)
+	cacheRepository := cache.NewCacheRepository(files.BASE_DIR)
 
 	// Initialize services
-	generatorService := generator.NewGenerateService(fileRepository)
+	generatorService := generator.NewGenerateService(fileRepository, cacheRepository)
+	graphService := graph.NewGraphService(fileRepository, generatorService)
+	watcherService := watcher.NewWatcherService(fileRepository, generatorService)
+	suggestService := suggest.NewSuggestService(fileRepository, generatorService)
+	migrateService := migrate.NewMigrateService(fileRepository, generatorService)
+	scaffoldService := scaffold.NewScaffoldService(fileRepository, generatorService)
+	injectService := inject.NewInjectService(fileRepository, generatorService)
 
 	// Initialize handlers
-	generateHandler := handlers.NewGenerateHandler(generatorService)
+	generateHandler := handlers.NewGenerateHandler(files.BASE_DIR, watcherService)
+	graphHandler := handlers.NewGraphHandler(graphService)
+	cacheHandler := handlers.NewCacheHandler(generatorService)
+	diffHandler := handlers.NewDiffHandler(files.BASE_DIR)
+	suggestHandler := handlers.NewSuggestHandler(suggestService)
+	migrateHandler := handlers.NewMigrateHandler(migrateService)
+	initHandler := handlers.NewInitHandler(scaffoldService)
+	injectHandler := handlers.NewInjectHandler(injectService)
 
 	// Initialize commands
 	rootCmd := commands.NewRootCommand()
 	rootCmd.AddCommand(commands.NewGenerateCommand(generateHandler))
+	rootCmd.AddCommand(commands.NewGraphCommand(graphHandler))
+	rootCmd.AddCommand(commands.NewCacheCommand(cacheHandler))
+	rootCmd.AddCommand(commands.NewDiffCommand(diffHandler))
+	rootCmd.AddCommand(commands.NewSuggestCommand(suggestHandler))
+	rootCmd.AddCommand(commands.NewMigrateCommand(migrateHandler))
+	rootCmd.AddCommand(commands.NewInitCommand(initHandler))
+	rootCmd.AddCommand(commands.NewInjectCommand(injectHandler))
 
 	// Execute the root command
 	if err := rootCmd.Execute(); err != nil {
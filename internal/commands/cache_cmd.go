@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCommand(cacheHandler handlers.CacheHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the scan cache",
+		Long:  "Manage the scan cache stored in .wiresetgen/cache",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clean",
+		Short: "Remove the scan cache",
+		Long:  "Remove the scan cache, the next run scans every file again",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cacheHandler.CleanCache(); err != nil {
+				logrus.Error("Error cleaning cache:", err)
+			} else {
+				logrus.Info("Cache cleaned successfully")
+			}
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,7 @@
+package commands
+
+import "errors"
+
+var (
+	ErrConflictingFlags = errors.New("flags cannot be used together")
+)
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewDiffCommand(diffHandler handlers.DiffHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare wire sets between git revisions",
+		Long:  "Compare wire sets between git revisions and report the providers each set gained or lost",
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			setDiffs, err := diffHandler.DiffWireSet(cmd.Context(), &models.DiffOptions{
+				From:    from,
+				To:      to,
+				Verbose: verbose,
+				Jobs:    jobs,
+			})
+			if err != nil {
+				logrus.Error("Error comparing wire sets:", err)
+				return
+			}
+
+			if len(setDiffs) == 0 {
+				logrus.Info("No wire set changes")
+				return
+			}
+
+			for _, setDiff := range setDiffs {
+				fmt.Fprintln(cmd.OutOrStdout(), setDiff.Name)
+				for _, provider := range setDiff.Added {
+					fmt.Fprintf(cmd.OutOrStdout(), "  + %s.%s\n", provider.ImportPath, provider.Function)
+				}
+				for _, provider := range setDiff.Removed {
+					fmt.Fprintf(cmd.OutOrStdout(), "  - %s.%s\n", provider.ImportPath, provider.Function)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().String("from", "", "Git revision of the old side")
+	cmd.Flags().String("to", "", "Git revision of the new side, the working tree when empty")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	_ = cmd.MarkFlagRequired("from")
+	return cmd
+}
@@ -1,7 +1,14 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
 	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -13,15 +20,77 @@ func NewGenerateCommand(generateHandler handlers.GenerateHandler) *cobra.Command
 		Long:  "Generate wire set",
 		Run: func(cmd *cobra.Command, args []string) {
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			watch, _ := cmd.Flags().GetBool("watch")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			rev, _ := cmd.Flags().GetString("rev")
+			backend, _ := cmd.Flags().GetString("backend")
+			templatePath, _ := cmd.Flags().GetString("template")
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			// Watch mode writes every regeneration to the working tree
+			if watch && (dryRun || rev != "") {
+				logrus.Error("Error watching wire set:", fmt.Errorf("%w: --watch with --dry-run or --rev", ErrConflictingFlags))
+				return
+			}
+
+			if watch {
+				interval, _ := cmd.Flags().GetDuration("interval")
+				debounce, _ := cmd.Flags().GetDuration("debounce")
+
+				if err := generateHandler.WatchWireSet(ctx, &models.WatchOptions{
+					Interval: interval,
+					Debounce: debounce,
+					Verbose:  verbose,
+					NoCache:  noCache,
+					Jobs:     jobs,
+					Backend:  backend,
+					Template: templatePath,
+				}); err != nil {
+					logrus.Error("Error watching wire set:", err)
+				}
 
-			if err := generateHandler.GenerateWireSet(verbose); err != nil {
+				return
+			}
+
+			result, err := generateHandler.GenerateWireSet(ctx, &models.GenerateOptions{
+				Verbose:  verbose,
+				NoCache:  noCache,
+				Jobs:     jobs,
+				DryRun:   dryRun,
+				Rev:      rev,
+				Backend:  backend,
+				Template: templatePath,
+			})
+			if err != nil {
 				logrus.Error("Error generating wire set:", err)
-			} else {
-				logrus.Info("Wire set generated successfully")
+				return
 			}
+
+			// Files generated from a revision are never written to the working tree
+			if dryRun || rev != "" {
+				for _, file := range result.Files {
+					fmt.Fprintf(cmd.OutOrStdout(), "// %s\n%s\n", file.Path, file.Content)
+				}
+				return
+			}
+
+			logrus.Info("Wire set generated successfully")
 		},
 	}
 
 	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("no-cache", false, "Scan every file without reading or writing the scan cache")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	cmd.Flags().Bool("dry-run", false, "Print the generated files instead of writing them")
+	cmd.Flags().String("backend", "", "Output backend, wire, fx, plain, do or dig (default from the config, else wire)")
+	cmd.Flags().String("template", "", "A text/template file rendered instead of the backend template (default from the config)")
+	cmd.Flags().String("rev", "", "Generate from a git revision without checking it out, the files are printed")
+	cmd.Flags().BoolP("watch", "w", false, "Watch the module tree and regenerate on changes")
+	cmd.Flags().Duration("interval", 500*time.Millisecond, "Polling interval for watch mode")
+	cmd.Flags().Duration("debounce", 300*time.Millisecond, "Quiet period before regenerating in watch mode")
 	return cmd
 }
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"testing"
+
+	mock_handlers "github.com/graphzc/wiresetgen/internal/handlers/mock"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCommand_watchConflicts(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "Dry run", args: []string{"--watch", "--backend", "fx", "--dry-run"}},
+		{name: "Revision", args: []string{"--watch", "--rev", "HEAD~1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			hook.Reset()
+
+			// The handler has no expectations, any call fails the test
+			cmd := NewGenerateCommand(mock_handlers.NewGenerateHandler(tt))
+			cmd.SetArgs(tc.args)
+
+			assert.NoError(tt, cmd.Execute())
+			if assert.NotNil(tt, hook.LastEntry()) {
+				assert.Equal(tt, logrus.ErrorLevel, hook.LastEntry().Level)
+				assert.Contains(tt, hook.LastEntry().Message, ErrConflictingFlags.Error())
+			}
+		})
+	}
+}
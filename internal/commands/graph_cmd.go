@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/graph"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewGraphCommand(graphHandler handlers.GraphHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export provider dependency graph",
+		Long:  "Export provider dependency graph in Graphviz DOT or Mermaid format, types are nodes, providers are edges and sets are clusters",
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			format, _ := cmd.Flags().GetString("format")
+			setName, _ := cmd.Flags().GetString("set")
+			injectorName, _ := cmd.Flags().GetString("injector")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			output, err := graphHandler.ExportGraph(cmd.Context(), &models.GraphOptions{
+				Format:       format,
+				SetName:      setName,
+				InjectorName: injectorName,
+				Verbose:      verbose,
+				NoCache:      noCache,
+				Jobs:         jobs,
+			})
+			if err != nil {
+				logrus.Error("Error exporting graph:", err)
+				return
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), output)
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("no-cache", false, "Scan every file without reading or writing the scan cache")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	cmd.Flags().StringP("format", "f", graph.FormatDOT, "Output format (dot, mermaid)")
+	cmd.Flags().StringP("set", "s", "", "Only include the given wire set")
+	cmd.Flags().StringP("injector", "i", "", "Only include the sets used by the given injector function")
+	return cmd
+}
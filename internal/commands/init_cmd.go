@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewInitCommand(initHandler handlers.InitHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init [dir]",
+		Short: "Create a wireinject file and a starter config",
+		Long:  "Create a wire.go injector file with the wireinject build tag in the directory and a starter .wiresetgen.yaml in the project root, existing files are never overwritten",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			packageName, _ := cmd.Flags().GetString("package")
+			goGenerate, _ := cmd.Flags().GetBool("go-generate")
+
+			directory := "."
+			if len(args) > 0 {
+				directory = args[0]
+			}
+
+			result, err := initHandler.Init(cmd.Context(), &models.InitOptions{
+				Directory:   directory,
+				PackageName: packageName,
+				GoGenerate:  goGenerate,
+				Verbose:     verbose,
+			})
+			if err != nil {
+				logrus.Error("Error initializing:", err)
+				return
+			}
+
+			for _, file := range result.Files {
+				fmt.Fprintf(cmd.OutOrStdout(), "created %s\n", filepath.Join(file.DirectoryPath, file.FileName))
+			}
+			for _, filePath := range result.Skipped {
+				fmt.Fprintf(cmd.OutOrStdout(), "kept existing %s\n", filePath)
+			}
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().StringP("package", "p", "", "Package name of the injector file, read from the directory when empty")
+	cmd.Flags().Bool("go-generate", false, "Also create a file with a //go:generate directive running wiresetgen generate")
+	return cmd
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewInjectCommand(injectHandler handlers.InjectHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inject",
+		Short: "Add an injector function to the wireinject file",
+		Long:  "Add an injector function that builds the given type from the given sets to the wireinject file, the injector returns a cleanup and an error when a provider of the sets does",
+		Run: func(cmd *cobra.Command, args []string) {
+			typeName, _ := cmd.Flags().GetString("type")
+			sets, _ := cmd.Flags().GetStringSlice("sets")
+			name, _ := cmd.Flags().GetString("name")
+			directory, _ := cmd.Flags().GetString("dir")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			file, err := injectHandler.GenerateInjector(cmd.Context(), &models.InjectOptions{
+				Type:      typeName,
+				Sets:      sets,
+				Name:      name,
+				Directory: directory,
+				DryRun:    dryRun,
+				Verbose:   verbose,
+				NoCache:   noCache,
+				Jobs:      jobs,
+			})
+			if err != nil {
+				logrus.Error("Error generating injector:", err)
+				return
+			}
+
+			if dryRun {
+				fmt.Fprintf(cmd.OutOrStdout(), "// %s\n%s", filepath.Join(file.DirectoryPath, file.FileName), file.Content)
+				return
+			}
+
+			logrus.Infof("Injector added to %s, run wire to generate it\n", filepath.Join(file.DirectoryPath, file.FileName))
+		},
+	}
+
+	cmd.Flags().String("type", "", "Type built by the injector, e.g. *app.App or example.com/app.App")
+	cmd.Flags().StringSlice("sets", nil, "Sets used by the injector, e.g. AppSet,ServiceSet")
+	cmd.Flags().String("name", "", "Name of the injector function (default Initialize<Type>)")
+	cmd.Flags().String("dir", "", "Directory of the wireinject file, needed when the project has several")
+	cmd.Flags().Bool("dry-run", false, "Print the wireinject file instead of writing it")
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("no-cache", false, "Scan every file without reading or writing the scan cache")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.MarkFlagRequired("sets")
+	return cmd
+}
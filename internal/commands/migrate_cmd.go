@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCommand(migrateHandler handlers.MigrateHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Convert hand-written wire.NewSet declarations into annotations",
+		Long:  "Convert hand-written var X = wire.NewSet(...) declarations into @WireSet annotations on the referenced functions, entries that are not functions are reported for manual follow-up",
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			comment, _ := cmd.Flags().GetBool("comment")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			result, err := migrateHandler.MigrateWireSets(cmd.Context(), &models.MigrateOptions{
+				Comment: comment,
+				DryRun:  dryRun,
+				Verbose: verbose,
+				NoCache: noCache,
+				Jobs:    jobs,
+			})
+			if err != nil {
+				logrus.Error("Error migrating wire sets:", err)
+				return
+			}
+
+			if len(result.Sets) == 0 {
+				logrus.Info("No wire.NewSet declarations found")
+				return
+			}
+
+			for _, set := range result.Sets {
+				action := "left unchanged"
+				if set.Removed {
+					action = "removed"
+				} else if set.Commented {
+					action = "commented out"
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s migrated %d functions to %s, %s\n",
+					set.FilePath, set.Line, set.VarName, len(set.Functions), set.Annotation, action)
+				for _, entry := range set.Unmigrated {
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s:%d: %s: %s\n", set.FilePath, entry.Line, entry.Expression, entry.Reason)
+				}
+			}
+
+			if dryRun {
+				for _, file := range result.Files {
+					fmt.Fprintf(cmd.OutOrStdout(), "// %s\n%s\n", filepath.Join(file.DirectoryPath, file.FileName), file.Content)
+				}
+				return
+			}
+
+			logrus.Info("Wire sets migrated, run wiresetgen generate and use the generated sets in the injectors")
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("comment", false, "Comment out the migrated declarations instead of removing them")
+	cmd.Flags().Bool("dry-run", false, "Print the migrated files instead of writing them")
+	cmd.Flags().Bool("no-cache", false, "Scan every file without reading or writing the scan cache")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+
+	cmd.AddCommand(newMigrateStyleCommand(migrateHandler))
+	return cmd
+}
+
+func newMigrateStyleCommand(migrateHandler handlers.MigrateHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "style [" + generator.StyleAnnotation + "|" + generator.StyleDirective + "]",
+		Short: "Convert the annotations between the @WireSet and //wiresetgen:set styles",
+		Long:  "Rewrite every @WireSet annotation as a //wiresetgen:set directive or the other way around, keeping the arguments, the style of the config is used when none is given",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			style := ""
+			if len(args) > 0 {
+				style = args[0]
+			}
+
+			result, err := migrateHandler.ConvertAnnotations(cmd.Context(), &models.ConvertOptions{
+				Style:   style,
+				DryRun:  dryRun,
+				Verbose: verbose,
+				Jobs:    jobs,
+			})
+			if err != nil {
+				logrus.Error("Error converting annotations:", err)
+				return
+			}
+
+			if len(result.Annotations) == 0 {
+				logrus.Infof("Every annotation is already in the %s style\n", result.Style)
+				return
+			}
+
+			for _, annotation := range result.Annotations {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s converted to %s\n",
+					annotation.FilePath, annotation.Line, annotation.FunctionName, generator.AnnotationComment(annotation.SetName, result.Style))
+			}
+
+			if dryRun {
+				for _, file := range result.Files {
+					fmt.Fprintf(cmd.OutOrStdout(), "// %s\n%s\n", filepath.Join(file.DirectoryPath, file.FileName), file.Content)
+				}
+				return
+			}
+
+			logrus.Infof("Annotations converted to the %s style\n", result.Style)
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("dry-run", false, "Print the converted files instead of writing them")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	return cmd
+}
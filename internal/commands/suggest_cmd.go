@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/graphzc/wiresetgen/internal/handlers"
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewSuggestCommand(suggestHandler handlers.SuggestHandler) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggest",
+		Short: "Find constructors missing a @WireSet annotation",
+		Long:  "Find exported New* constructors without annotation in packages whose annotations all name the same set, and add the annotation with --fix",
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			fix, _ := cmd.Flags().GetBool("fix")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+
+			suggestions, err := suggestHandler.SuggestAnnotations(cmd.Context(), &models.SuggestOptions{
+				Fix:     fix,
+				Verbose: verbose,
+				NoCache: noCache,
+				Jobs:    jobs,
+			})
+			if err != nil {
+				logrus.Error("Error suggesting annotations:", err)
+				return
+			}
+
+			if len(suggestions) == 0 {
+				logrus.Info("No missing annotations")
+				return
+			}
+
+			for _, suggestion := range suggestions {
+				if fix {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: added %s to %s\n", suggestion.FilePath, suggestion.Line, suggestion.Annotation, suggestion.FunctionName)
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s is missing %s\n", suggestion.FilePath, suggestion.Line, suggestion.FunctionName, suggestion.Annotation)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().Bool("fix", false, "Add the missing annotations to the source files")
+	cmd.Flags().Bool("no-cache", false, "Scan every file without reading or writing the scan cache")
+	cmd.Flags().IntP("jobs", "j", runtime.GOMAXPROCS(0), "Number of files scanned concurrently")
+	return cmd
+}
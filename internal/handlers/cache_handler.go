@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+)
+
+type CacheHandler interface {
+	CleanCache() error
+}
+
+type cacheHandlerImpl struct {
+	generatorService generator.Service
+}
+
+func NewCacheHandler(generatorService generator.Service) CacheHandler {
+	return &cacheHandlerImpl{
+		generatorService: generatorService,
+	}
+}
+
+func (c *cacheHandlerImpl) CleanCache() error {
+	return c.generatorService.CleanCache()
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/pkg/wiresetgen"
+)
+
+type DiffHandler interface {
+	DiffWireSet(ctx context.Context, options *models.DiffOptions) ([]*wiresetgen.SetDiff, error)
+}
+
+type diffHandlerImpl struct {
+	baseDir string
+}
+
+func NewDiffHandler(baseDir string) DiffHandler {
+	return &diffHandlerImpl{
+		baseDir: baseDir,
+	}
+}
+
+func (d *diffHandlerImpl) DiffWireSet(ctx context.Context, options *models.DiffOptions) ([]*wiresetgen.SetDiff, error) {
+	return wiresetgen.Diff(ctx, wiresetgen.DiffOptions{
+		Dir:     d.baseDir,
+		From:    options.From,
+		To:      options.To,
+		Verbose: options.Verbose,
+		Jobs:    options.Jobs,
+	})
+}
@@ -1,23 +1,43 @@
 package handlers
 
 import (
-	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/watcher"
+	"github.com/graphzc/wiresetgen/pkg/wiresetgen"
 )
 
 type GenerateHandler interface {
-	GenerateWireSet(verbose bool) error
+	GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*wiresetgen.Result, error)
+	WatchWireSet(ctx context.Context, options *models.WatchOptions) error
 }
 
 type generateHandlerImpl struct {
-	generatorService generator.Service
+	baseDir        string
+	watcherService watcher.Service
 }
 
-func NewGenerateHandler(generatorService generator.Service) GenerateHandler {
+func NewGenerateHandler(baseDir string, watcherService watcher.Service) GenerateHandler {
 	return &generateHandlerImpl{
-		generatorService: generatorService,
+		baseDir:        baseDir,
+		watcherService: watcherService,
 	}
 }
 
-func (g *generateHandlerImpl) GenerateWireSet(verbose bool) error {
-	return g.generatorService.GenerateWireSet(verbose)
+func (g *generateHandlerImpl) GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*wiresetgen.Result, error) {
+	return wiresetgen.Generate(ctx, wiresetgen.Options{
+		Dir:      g.baseDir,
+		Verbose:  options.Verbose,
+		NoCache:  options.NoCache,
+		Jobs:     options.Jobs,
+		DryRun:   options.DryRun,
+		Rev:      options.Rev,
+		Backend:  options.Backend,
+		Template: options.Template,
+	})
+}
+
+func (g *generateHandlerImpl) WatchWireSet(ctx context.Context, options *models.WatchOptions) error {
+	return g.watcherService.Watch(ctx, options)
 }
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/graph"
+)
+
+type GraphHandler interface {
+	ExportGraph(ctx context.Context, options *models.GraphOptions) (string, error)
+}
+
+type graphHandlerImpl struct {
+	graphService graph.Service
+}
+
+func NewGraphHandler(graphService graph.Service) GraphHandler {
+	return &graphHandlerImpl{
+		graphService: graphService,
+	}
+}
+
+func (g *graphHandlerImpl) ExportGraph(ctx context.Context, options *models.GraphOptions) (string, error) {
+	return g.graphService.ExportGraph(ctx, options)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/scaffold"
+)
+
+type InitHandler interface {
+	Init(ctx context.Context, options *models.InitOptions) (*models.InitResult, error)
+}
+
+type initHandlerImpl struct {
+	scaffoldService scaffold.Service
+}
+
+func NewInitHandler(scaffoldService scaffold.Service) InitHandler {
+	return &initHandlerImpl{
+		scaffoldService: scaffoldService,
+	}
+}
+
+func (i *initHandlerImpl) Init(ctx context.Context, options *models.InitOptions) (*models.InitResult, error) {
+	return i.scaffoldService.Init(ctx, options)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/inject"
+)
+
+type InjectHandler interface {
+	GenerateInjector(ctx context.Context, options *models.InjectOptions) (*models.GeneratedFile, error)
+}
+
+type injectHandlerImpl struct {
+	injectService inject.Service
+}
+
+func NewInjectHandler(injectService inject.Service) InjectHandler {
+	return &injectHandlerImpl{
+		injectService: injectService,
+	}
+}
+
+func (i *injectHandlerImpl) GenerateInjector(ctx context.Context, options *models.InjectOptions) (*models.GeneratedFile, error) {
+	return i.injectService.GenerateInjector(ctx, options)
+}
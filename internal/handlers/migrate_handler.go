@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/migrate"
+)
+
+type MigrateHandler interface {
+	MigrateWireSets(ctx context.Context, options *models.MigrateOptions) (*models.MigrateResult, error)
+	ConvertAnnotations(ctx context.Context, options *models.ConvertOptions) (*models.ConvertResult, error)
+}
+
+type migrateHandlerImpl struct {
+	migrateService migrate.Service
+}
+
+func NewMigrateHandler(migrateService migrate.Service) MigrateHandler {
+	return &migrateHandlerImpl{
+		migrateService: migrateService,
+	}
+}
+
+func (m *migrateHandlerImpl) MigrateWireSets(ctx context.Context, options *models.MigrateOptions) (*models.MigrateResult, error) {
+	return m.migrateService.MigrateWireSets(ctx, options)
+}
+
+func (m *migrateHandlerImpl) ConvertAnnotations(ctx context.Context, options *models.ConvertOptions) (*models.ConvertResult, error) {
+	return m.migrateService.ConvertAnnotations(ctx, options)
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_handlers
+
+import mock "github.com/stretchr/testify/mock"
+
+// CacheHandler is an autogenerated mock type for the CacheHandler type
+type CacheHandler struct {
+	mock.Mock
+}
+
+type CacheHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CacheHandler) EXPECT() *CacheHandler_Expecter {
+	return &CacheHandler_Expecter{mock: &_m.Mock}
+}
+
+// CleanCache provides a mock function with no fields
+func (_m *CacheHandler) CleanCache() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CacheHandler_CleanCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanCache'
+type CacheHandler_CleanCache_Call struct {
+	*mock.Call
+}
+
+// CleanCache is a helper method to define mock.On call
+func (_e *CacheHandler_Expecter) CleanCache() *CacheHandler_CleanCache_Call {
+	return &CacheHandler_CleanCache_Call{Call: _e.mock.On("CleanCache")}
+}
+
+func (_c *CacheHandler_CleanCache_Call) Run(run func()) *CacheHandler_CleanCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *CacheHandler_CleanCache_Call) Return(_a0 error) *CacheHandler_CleanCache_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CacheHandler_CleanCache_Call) RunAndReturn(run func() error) *CacheHandler_CleanCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCacheHandler creates a new instance of CacheHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCacheHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CacheHandler {
+	mock := &CacheHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
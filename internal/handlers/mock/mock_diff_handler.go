@@ -0,0 +1,99 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_handlers
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+
+	wiresetgen "github.com/graphzc/wiresetgen/pkg/wiresetgen"
+)
+
+// DiffHandler is an autogenerated mock type for the DiffHandler type
+type DiffHandler struct {
+	mock.Mock
+}
+
+type DiffHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DiffHandler) EXPECT() *DiffHandler_Expecter {
+	return &DiffHandler_Expecter{mock: &_m.Mock}
+}
+
+// DiffWireSet provides a mock function with given fields: ctx, options
+func (_m *DiffHandler) DiffWireSet(ctx context.Context, options *models.DiffOptions) ([]*wiresetgen.SetDiff, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffWireSet")
+	}
+
+	var r0 []*wiresetgen.SetDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DiffOptions) ([]*wiresetgen.SetDiff, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DiffOptions) []*wiresetgen.SetDiff); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*wiresetgen.SetDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DiffOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffHandler_DiffWireSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffWireSet'
+type DiffHandler_DiffWireSet_Call struct {
+	*mock.Call
+}
+
+// DiffWireSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.DiffOptions
+func (_e *DiffHandler_Expecter) DiffWireSet(ctx interface{}, options interface{}) *DiffHandler_DiffWireSet_Call {
+	return &DiffHandler_DiffWireSet_Call{Call: _e.mock.On("DiffWireSet", ctx, options)}
+}
+
+func (_c *DiffHandler_DiffWireSet_Call) Run(run func(ctx context.Context, options *models.DiffOptions)) *DiffHandler_DiffWireSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DiffOptions))
+	})
+	return _c
+}
+
+func (_c *DiffHandler_DiffWireSet_Call) Return(_a0 []*wiresetgen.SetDiff, _a1 error) *DiffHandler_DiffWireSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DiffHandler_DiffWireSet_Call) RunAndReturn(run func(context.Context, *models.DiffOptions) ([]*wiresetgen.SetDiff, error)) *DiffHandler_DiffWireSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDiffHandler creates a new instance of DiffHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDiffHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DiffHandler {
+	mock := &DiffHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,7 +2,15 @@
 
 package mock_handlers
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+
+	wiresetgen "github.com/graphzc/wiresetgen/pkg/wiresetgen"
+)
 
 // GenerateHandler is an autogenerated mock type for the GenerateHandler type
 type GenerateHandler struct {
@@ -17,22 +25,34 @@ func (_m *GenerateHandler) EXPECT() *GenerateHandler_Expecter {
 	return &GenerateHandler_Expecter{mock: &_m.Mock}
 }
 
-// GenerateWireSet provides a mock function with given fields: verbose
-func (_m *GenerateHandler) GenerateWireSet(verbose bool) error {
-	ret := _m.Called(verbose)
+// GenerateWireSet provides a mock function with given fields: ctx, options
+func (_m *GenerateHandler) GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*wiresetgen.Result, error) {
+	ret := _m.Called(ctx, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateWireSet")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(bool) error); ok {
-		r0 = rf(verbose)
+	var r0 *wiresetgen.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) (*wiresetgen.Result, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) *wiresetgen.Result); ok {
+		r0 = rf(ctx, options)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wiresetgen.Result)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GenerateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GenerateHandler_GenerateWireSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateWireSet'
@@ -41,24 +61,72 @@ type GenerateHandler_GenerateWireSet_Call struct {
 }
 
 // GenerateWireSet is a helper method to define mock.On call
-//   - verbose bool
-func (_e *GenerateHandler_Expecter) GenerateWireSet(verbose interface{}) *GenerateHandler_GenerateWireSet_Call {
-	return &GenerateHandler_GenerateWireSet_Call{Call: _e.mock.On("GenerateWireSet", verbose)}
+//   - ctx context.Context
+//   - options *models.GenerateOptions
+func (_e *GenerateHandler_Expecter) GenerateWireSet(ctx interface{}, options interface{}) *GenerateHandler_GenerateWireSet_Call {
+	return &GenerateHandler_GenerateWireSet_Call{Call: _e.mock.On("GenerateWireSet", ctx, options)}
+}
+
+func (_c *GenerateHandler_GenerateWireSet_Call) Run(run func(ctx context.Context, options *models.GenerateOptions)) *GenerateHandler_GenerateWireSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.GenerateOptions))
+	})
+	return _c
+}
+
+func (_c *GenerateHandler_GenerateWireSet_Call) Return(_a0 *wiresetgen.Result, _a1 error) *GenerateHandler_GenerateWireSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GenerateHandler_GenerateWireSet_Call) RunAndReturn(run func(context.Context, *models.GenerateOptions) (*wiresetgen.Result, error)) *GenerateHandler_GenerateWireSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchWireSet provides a mock function with given fields: ctx, options
+func (_m *GenerateHandler) WatchWireSet(ctx context.Context, options *models.WatchOptions) error {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchWireSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WatchOptions) error); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateHandler_WatchWireSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchWireSet'
+type GenerateHandler_WatchWireSet_Call struct {
+	*mock.Call
+}
+
+// WatchWireSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.WatchOptions
+func (_e *GenerateHandler_Expecter) WatchWireSet(ctx interface{}, options interface{}) *GenerateHandler_WatchWireSet_Call {
+	return &GenerateHandler_WatchWireSet_Call{Call: _e.mock.On("WatchWireSet", ctx, options)}
 }
 
-func (_c *GenerateHandler_GenerateWireSet_Call) Run(run func(verbose bool)) *GenerateHandler_GenerateWireSet_Call {
+func (_c *GenerateHandler_WatchWireSet_Call) Run(run func(ctx context.Context, options *models.WatchOptions)) *GenerateHandler_WatchWireSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(bool))
+		run(args[0].(context.Context), args[1].(*models.WatchOptions))
 	})
 	return _c
 }
 
-func (_c *GenerateHandler_GenerateWireSet_Call) Return(_a0 error) *GenerateHandler_GenerateWireSet_Call {
+func (_c *GenerateHandler_WatchWireSet_Call) Return(_a0 error) *GenerateHandler_WatchWireSet_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *GenerateHandler_GenerateWireSet_Call) RunAndReturn(run func(bool) error) *GenerateHandler_GenerateWireSet_Call {
+func (_c *GenerateHandler_WatchWireSet_Call) RunAndReturn(run func(context.Context, *models.WatchOptions) error) *GenerateHandler_WatchWireSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_handlers
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
+
+// InitHandler is an autogenerated mock type for the InitHandler type
+type InitHandler struct {
+	mock.Mock
+}
+
+type InitHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InitHandler) EXPECT() *InitHandler_Expecter {
+	return &InitHandler_Expecter{mock: &_m.Mock}
+}
+
+// Init provides a mock function with given fields: ctx, options
+func (_m *InitHandler) Init(ctx context.Context, options *models.InitOptions) (*models.InitResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Init")
+	}
+
+	var r0 *models.InitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InitOptions) (*models.InitResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InitOptions) *models.InitResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InitResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.InitOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InitHandler_Init_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Init'
+type InitHandler_Init_Call struct {
+	*mock.Call
+}
+
+// Init is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.InitOptions
+func (_e *InitHandler_Expecter) Init(ctx interface{}, options interface{}) *InitHandler_Init_Call {
+	return &InitHandler_Init_Call{Call: _e.mock.On("Init", ctx, options)}
+}
+
+func (_c *InitHandler_Init_Call) Run(run func(ctx context.Context, options *models.InitOptions)) *InitHandler_Init_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.InitOptions))
+	})
+	return _c
+}
+
+func (_c *InitHandler_Init_Call) Return(_a0 *models.InitResult, _a1 error) *InitHandler_Init_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InitHandler_Init_Call) RunAndReturn(run func(context.Context, *models.InitOptions) (*models.InitResult, error)) *InitHandler_Init_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInitHandler creates a new instance of InitHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInitHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InitHandler {
+	mock := &InitHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_handlers
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
+
+// InjectHandler is an autogenerated mock type for the InjectHandler type
+type InjectHandler struct {
+	mock.Mock
+}
+
+type InjectHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InjectHandler) EXPECT() *InjectHandler_Expecter {
+	return &InjectHandler_Expecter{mock: &_m.Mock}
+}
+
+// GenerateInjector provides a mock function with given fields: ctx, options
+func (_m *InjectHandler) GenerateInjector(ctx context.Context, options *models.InjectOptions) (*models.GeneratedFile, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateInjector")
+	}
+
+	var r0 *models.GeneratedFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InjectOptions) (*models.GeneratedFile, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InjectOptions) *models.GeneratedFile); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GeneratedFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.InjectOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InjectHandler_GenerateInjector_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateInjector'
+type InjectHandler_GenerateInjector_Call struct {
+	*mock.Call
+}
+
+// GenerateInjector is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.InjectOptions
+func (_e *InjectHandler_Expecter) GenerateInjector(ctx interface{}, options interface{}) *InjectHandler_GenerateInjector_Call {
+	return &InjectHandler_GenerateInjector_Call{Call: _e.mock.On("GenerateInjector", ctx, options)}
+}
+
+func (_c *InjectHandler_GenerateInjector_Call) Run(run func(ctx context.Context, options *models.InjectOptions)) *InjectHandler_GenerateInjector_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.InjectOptions))
+	})
+	return _c
+}
+
+func (_c *InjectHandler_GenerateInjector_Call) Return(_a0 *models.GeneratedFile, _a1 error) *InjectHandler_GenerateInjector_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InjectHandler_GenerateInjector_Call) RunAndReturn(run func(context.Context, *models.InjectOptions) (*models.GeneratedFile, error)) *InjectHandler_GenerateInjector_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInjectHandler creates a new instance of InjectHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInjectHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InjectHandler {
+	mock := &InjectHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,156 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_handlers
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
+
+// MigrateHandler is an autogenerated mock type for the MigrateHandler type
+type MigrateHandler struct {
+	mock.Mock
+}
+
+type MigrateHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MigrateHandler) EXPECT() *MigrateHandler_Expecter {
+	return &MigrateHandler_Expecter{mock: &_m.Mock}
+}
+
+// ConvertAnnotations provides a mock function with given fields: ctx, options
+func (_m *MigrateHandler) ConvertAnnotations(ctx context.Context, options *models.ConvertOptions) (*models.ConvertResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConvertAnnotations")
+	}
+
+	var r0 *models.ConvertResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConvertOptions) (*models.ConvertResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConvertOptions) *models.ConvertResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConvertResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ConvertOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateHandler_ConvertAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConvertAnnotations'
+type MigrateHandler_ConvertAnnotations_Call struct {
+	*mock.Call
+}
+
+// ConvertAnnotations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.ConvertOptions
+func (_e *MigrateHandler_Expecter) ConvertAnnotations(ctx interface{}, options interface{}) *MigrateHandler_ConvertAnnotations_Call {
+	return &MigrateHandler_ConvertAnnotations_Call{Call: _e.mock.On("ConvertAnnotations", ctx, options)}
+}
+
+func (_c *MigrateHandler_ConvertAnnotations_Call) Run(run func(ctx context.Context, options *models.ConvertOptions)) *MigrateHandler_ConvertAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ConvertOptions))
+	})
+	return _c
+}
+
+func (_c *MigrateHandler_ConvertAnnotations_Call) Return(_a0 *models.ConvertResult, _a1 error) *MigrateHandler_ConvertAnnotations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MigrateHandler_ConvertAnnotations_Call) RunAndReturn(run func(context.Context, *models.ConvertOptions) (*models.ConvertResult, error)) *MigrateHandler_ConvertAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrateWireSets provides a mock function with given fields: ctx, options
+func (_m *MigrateHandler) MigrateWireSets(ctx context.Context, options *models.MigrateOptions) (*models.MigrateResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateWireSets")
+	}
+
+	var r0 *models.MigrateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MigrateOptions) (*models.MigrateResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MigrateOptions) *models.MigrateResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MigrateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.MigrateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateHandler_MigrateWireSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateWireSets'
+type MigrateHandler_MigrateWireSets_Call struct {
+	*mock.Call
+}
+
+// MigrateWireSets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.MigrateOptions
+func (_e *MigrateHandler_Expecter) MigrateWireSets(ctx interface{}, options interface{}) *MigrateHandler_MigrateWireSets_Call {
+	return &MigrateHandler_MigrateWireSets_Call{Call: _e.mock.On("MigrateWireSets", ctx, options)}
+}
+
+func (_c *MigrateHandler_MigrateWireSets_Call) Run(run func(ctx context.Context, options *models.MigrateOptions)) *MigrateHandler_MigrateWireSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.MigrateOptions))
+	})
+	return _c
+}
+
+func (_c *MigrateHandler_MigrateWireSets_Call) Return(_a0 *models.MigrateResult, _a1 error) *MigrateHandler_MigrateWireSets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MigrateHandler_MigrateWireSets_Call) RunAndReturn(run func(context.Context, *models.MigrateOptions) (*models.MigrateResult, error)) *MigrateHandler_MigrateWireSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMigrateHandler creates a new instance of MigrateHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMigrateHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MigrateHandler {
+	mock := &MigrateHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
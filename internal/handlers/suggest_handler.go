@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/suggest"
+)
+
+type SuggestHandler interface {
+	SuggestAnnotations(ctx context.Context, options *models.SuggestOptions) ([]*models.Suggestion, error)
+}
+
+type suggestHandlerImpl struct {
+	suggestService suggest.Service
+}
+
+func NewSuggestHandler(suggestService suggest.Service) SuggestHandler {
+	return &suggestHandlerImpl{
+		suggestService: suggestService,
+	}
+}
+
+func (s *suggestHandlerImpl) SuggestAnnotations(ctx context.Context, options *models.SuggestOptions) ([]*models.Suggestion, error) {
+	return s.suggestService.SuggestAnnotations(ctx, options)
+}
@@ -0,0 +1,22 @@
+package models
+
+type Annotation struct {
+	Line         int
+	Column       int
+	SetName      string
+	FunctionName string
+	Err          error
+
+	// The annotation is a //wiresetgen:set directive instead of a @WireSet comment
+	Directive bool
+
+	// Optional arguments, the name of the provided value and the interface it is bound to
+	Name string
+	Bind string
+
+	// The profiles the provider is in, it is in every profile when empty
+	Profiles []string
+
+	// The problems that do not stop the annotation from being used, e.g. an unknown key
+	Warnings []error
+}
@@ -0,0 +1,20 @@
+package models
+
+type BuildTemplateModel struct {
+	Name    string
+	Params  []*InjectorParam
+	Results []string
+	Steps   []*BuildStep
+
+	// Values returned on success and when a provider fails
+	Returns      []string
+	ErrorReturns []string
+}
+
+// A provider call, the cleanups of the earlier steps run when it returns an error
+type BuildStep struct {
+	Vars     []string
+	Call     string
+	Error    bool
+	Cleanups []string
+}
@@ -0,0 +1,35 @@
+package models
+
+type Config struct {
+	Exclude []string `yaml:"exclude"`
+	Backend string   `yaml:"backend"`
+
+	// The backend of the locations by directory, e.g. cmd/worker: dig
+	Backends map[string]string `yaml:"backends"`
+
+	// The profiles of the locations by directory, e.g. cmd/api: [dev, prod]
+	Profiles map[string][]string `yaml:"profiles"`
+
+	// The build constraint of the generated files of the locations by directory, e.g. cmd/desktop: windows || darwin
+	Constraints map[string]string `yaml:"constraints"`
+
+	// A text/template file rendered instead of the backend template, relative to the project root
+	Template string `yaml:"template"`
+
+	// annotation (default) or directive, the style of the comments the suggest and migrate commands write
+	Style string `yaml:"style"`
+
+	Naming NamingConfig `yaml:"naming"`
+}
+
+// How a set name becomes the name of its generated variable
+type NamingConfig struct {
+	// suffix (default), prefix or exact
+	Strategy string `yaml:"strategy"`
+
+	// The word added by the suffix and prefix strategies, the default depends on the backend, e.g. Set or Module
+	Affix string `yaml:"affix"`
+
+	// exported or unexported, the case of the set name is kept when empty
+	Export string `yaml:"export"`
+}
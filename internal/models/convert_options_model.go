@@ -0,0 +1,9 @@
+package models
+
+type ConvertOptions struct {
+	// annotation or directive, the style of the config when empty
+	Style   string
+	DryRun  bool
+	Verbose bool
+	Jobs    int
+}
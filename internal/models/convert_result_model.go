@@ -0,0 +1,14 @@
+package models
+
+type ConvertResult struct {
+	Style       string
+	Annotations []*ConvertedAnnotation
+	Files       []*GeneratedFile
+}
+
+type ConvertedAnnotation struct {
+	FilePath     string
+	Line         int
+	FunctionName string
+	SetName      string
+}
@@ -0,0 +1,8 @@
+package models
+
+type DiffOptions struct {
+	From    string
+	To      string
+	Verbose bool
+	Jobs    int
+}
@@ -0,0 +1,9 @@
+package models
+
+type FileScan struct {
+	SetInfos        []*WireSetInfo
+	WireGenLocation *WireGenLocation
+
+	// The annotation problems of the file with their position, the annotations with an error are left out
+	Warnings []string
+}
@@ -0,0 +1,8 @@
+package models
+
+import "time"
+
+type FileStat struct {
+	Size    int64
+	ModTime time.Time
+}
@@ -0,0 +1,11 @@
+package models
+
+type GenerateOptions struct {
+	Verbose  bool
+	NoCache  bool
+	Jobs     int
+	DryRun   bool
+	Rev      string
+	Backend  string
+	Template string
+}
@@ -0,0 +1,6 @@
+package models
+
+type GenerateResult struct {
+	Scan  *ProjectScan
+	Files []*GeneratedFile
+}
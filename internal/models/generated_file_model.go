@@ -0,0 +1,10 @@
+package models
+
+type GeneratedFile struct {
+	DirectoryPath string
+	FileName      string
+	Content       string
+
+	// The sets left out of the file, with the reason
+	Skipped []string
+}
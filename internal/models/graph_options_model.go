@@ -0,0 +1,10 @@
+package models
+
+type GraphOptions struct {
+	Format       string
+	SetName      string
+	InjectorName string
+	Verbose      bool
+	NoCache      bool
+	Jobs         int
+}
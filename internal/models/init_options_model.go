@@ -0,0 +1,8 @@
+package models
+
+type InitOptions struct {
+	Directory   string
+	PackageName string
+	GoGenerate  bool
+	Verbose     bool
+}
@@ -0,0 +1,6 @@
+package models
+
+type InitResult struct {
+	Files   []*GeneratedFile
+	Skipped []string
+}
@@ -0,0 +1,6 @@
+package models
+
+type InitTemplateModel struct {
+	PackageName     string
+	GenerateCommand string
+}
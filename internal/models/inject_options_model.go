@@ -0,0 +1,12 @@
+package models
+
+type InjectOptions struct {
+	Type      string
+	Sets      []string
+	Name      string
+	Directory string
+	DryRun    bool
+	Verbose   bool
+	NoCache   bool
+	Jobs      int
+}
@@ -0,0 +1,15 @@
+package models
+
+type InjectorTemplateModel struct {
+	Name     string
+	TypeName string
+	WireName string
+	Params   []*InjectorParam
+	Results  []string
+	WireSets []string
+}
+
+type InjectorParam struct {
+	Name string
+	Type string
+}
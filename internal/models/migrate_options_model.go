@@ -0,0 +1,9 @@
+package models
+
+type MigrateOptions struct {
+	Comment bool
+	DryRun  bool
+	Verbose bool
+	NoCache bool
+	Jobs    int
+}
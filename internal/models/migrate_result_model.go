@@ -0,0 +1,24 @@
+package models
+
+type MigrateResult struct {
+	Sets  []*MigratedSet
+	Files []*GeneratedFile
+}
+
+type MigratedSet struct {
+	FilePath   string
+	Line       int
+	VarName    string
+	SetName    string
+	Annotation string
+	Functions  []string
+	Unmigrated []*UnmigratedEntry
+	Removed    bool
+	Commented  bool
+}
+
+type UnmigratedEntry struct {
+	Line       int
+	Expression string
+	Reason     string
+}
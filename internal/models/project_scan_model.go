@@ -0,0 +1,10 @@
+package models
+
+type ProjectScan struct {
+	ModuleName       string
+	SetInfos         []*WireSetInfo
+	WireGenLocations []*WireGenLocation
+
+	// The annotation problems of every file with their position
+	Warnings []string
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type ScanCache struct {
+	Version    int                        `json:"version"`
+	ModuleName string                     `json:"moduleName"`
+	Files      map[string]*CachedFileScan `json:"files"`
+}
+
+type CachedFileScan struct {
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	Hash     string    `json:"hash"`
+	FileScan *FileScan `json:"fileScan"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type SourceFile struct {
+	FilePath string
+	Content  string
+}
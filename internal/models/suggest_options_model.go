@@ -0,0 +1,8 @@
+package models
+
+type SuggestOptions struct {
+	Fix     bool
+	Verbose bool
+	NoCache bool
+	Jobs    int
+}
@@ -0,0 +1,11 @@
+package models
+
+type Suggestion struct {
+	FilePath     string
+	Line         int
+	FunctionName string
+	SetName      string
+
+	// The comment line that annotates the function, in the style of the config
+	Annotation string
+}
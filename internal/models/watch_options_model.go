@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type WatchOptions struct {
+	Interval time.Duration
+	Debounce time.Duration
+	Verbose  bool
+	NoCache  bool
+	Jobs     int
+
+	// The output backend and the template file of every regeneration, the config is used when empty
+	Backend  string
+	Template string
+}
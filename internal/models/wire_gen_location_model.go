@@ -3,4 +3,14 @@ package models
 type WireGenLocation struct {
 	PackageName   string
 	DirectoryPath string
+	FilePath      string
+
+	// Set by the //wiresetgen:backend directive of the injector file
+	Backend string
+
+	// Set by the //wiresetgen:profile directive of the injector file
+	Profiles []string
+
+	// The build constraint of the injector file without the wireinject tag
+	Constraint string
 }
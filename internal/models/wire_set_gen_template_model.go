@@ -1,7 +1,29 @@
 package models
 
+// The data of the generated file templates, custom templates get the same model
 type WireSetGenTemplateModel struct {
 	PackageName string
 	Imports     []*ImportTemplate
 	WireSets    []*WireSet
+
+	// The module and the import path of the location package
+	ModuleName string
+	ImportPath string
+
+	// The directory of the location, its wireinject file and the generated file name, relative to the module root
+	DirectoryPath string
+	InjectorFile  string
+	FileName      string
+
+	// The backend that selected the file name
+	Backend string
+
+	// The profile of the file, empty when the location has none
+	Profile string
+
+	// The //go:build expression added above the package clause, empty when the file is always built
+	Constraint string
+
+	// Only set for the plain backend
+	Build *BuildTemplateModel
 }
@@ -5,4 +5,21 @@ type WireSetInfo struct {
 	SetName      string
 	FunctionName string
 	ImportPath   string
+	FilePath     string
+	ParamTypes   []string
+	ResultTypes  []string
+
+	// Set by the name="..." and bind="..." annotation arguments, Bind is qualified like the types
+	Name string
+	Bind string
+
+	// Set by the profile="..." annotation argument, the provider is in every profile when empty
+	Profiles []string
+
+	// The build constraint of the file, from its //go:build line and file name, empty when it is always built
+	Constraint string
+
+	// The line of the function declaration and its doc comment without the annotation
+	Line int
+	Doc  string
 }
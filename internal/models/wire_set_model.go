@@ -2,5 +2,29 @@ package models
 
 type WireSet struct {
 	SetName  string
+	VarName  string
 	FuncPath []string
+
+	// The providers in the FuncPath order
+	Providers []*ProviderTemplate
+}
+
+// A provider as the templates write it, the types are qualified with the import aliases
+type ProviderTemplate struct {
+	FuncPath string
+	Type     string
+	Zero     string
+	Name     string
+	Bind     string
+	Params   []*InjectorParam
+	Cleanup  bool
+	Error    bool
+
+	// Where the provider is declared, the file path is relative to the module root
+	Function    string
+	PackageName string
+	ImportPath  string
+	FilePath    string
+	Line        int
+	Doc         string
 }
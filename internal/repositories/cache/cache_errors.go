@@ -0,0 +1,7 @@
+package cache
+
+import "errors"
+
+var (
+	ErrCacheNotFound = errors.New("cache not found")
+)
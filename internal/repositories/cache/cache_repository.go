@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+const (
+	CACHE_DIR       = ".wiresetgen/cache"
+	SCAN_CACHE_FILE = "scan.json"
+)
+
+type Repository interface {
+	LoadScanCache() (*models.ScanCache, error)
+	SaveScanCache(scanCache *models.ScanCache) error
+	Clean() error
+}
+
+type repositoryImpl struct {
+	cacheDir string
+}
+
+func NewCacheRepository(baseDir string) Repository {
+	return &repositoryImpl{
+		cacheDir: filepath.Join(baseDir, CACHE_DIR),
+	}
+}
+
+func (c *repositoryImpl) LoadScanCache() (*models.ScanCache, error) {
+	data, err := os.ReadFile(filepath.Join(c.cacheDir, SCAN_CACHE_FILE))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCacheNotFound
+		}
+		return nil, err
+	}
+
+	scanCache := &models.ScanCache{}
+	if err := json.Unmarshal(data, scanCache); err != nil {
+		return nil, err
+	}
+
+	return scanCache, nil
+}
+
+func (c *repositoryImpl) SaveScanCache(scanCache *models.ScanCache) error {
+	data, err := json.Marshal(scanCache)
+	if err != nil {
+		return err
+	}
+
+	// Create the directory if it doesn't exist
+	if err := os.MkdirAll(c.cacheDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a concurrent run never reads a partial cache
+	tmpFile := filepath.Join(c.cacheDir, SCAN_CACHE_FILE+".tmp")
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, filepath.Join(c.cacheDir, SCAN_CACHE_FILE))
+}
+
+func (c *repositoryImpl) Clean() error {
+	return os.RemoveAll(c.cacheDir)
+}
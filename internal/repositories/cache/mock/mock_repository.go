@@ -0,0 +1,183 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_cache
+
+import (
+	models "github.com/graphzc/wiresetgen/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// Clean provides a mock function with no fields
+func (_m *Repository) Clean() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clean")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_Clean_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clean'
+type Repository_Clean_Call struct {
+	*mock.Call
+}
+
+// Clean is a helper method to define mock.On call
+func (_e *Repository_Expecter) Clean() *Repository_Clean_Call {
+	return &Repository_Clean_Call{Call: _e.mock.On("Clean")}
+}
+
+func (_c *Repository_Clean_Call) Run(run func()) *Repository_Clean_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Repository_Clean_Call) Return(_a0 error) *Repository_Clean_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_Clean_Call) RunAndReturn(run func() error) *Repository_Clean_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadScanCache provides a mock function with no fields
+func (_m *Repository) LoadScanCache() (*models.ScanCache, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadScanCache")
+	}
+
+	var r0 *models.ScanCache
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*models.ScanCache, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *models.ScanCache); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScanCache)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_LoadScanCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadScanCache'
+type Repository_LoadScanCache_Call struct {
+	*mock.Call
+}
+
+// LoadScanCache is a helper method to define mock.On call
+func (_e *Repository_Expecter) LoadScanCache() *Repository_LoadScanCache_Call {
+	return &Repository_LoadScanCache_Call{Call: _e.mock.On("LoadScanCache")}
+}
+
+func (_c *Repository_LoadScanCache_Call) Run(run func()) *Repository_LoadScanCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Repository_LoadScanCache_Call) Return(_a0 *models.ScanCache, _a1 error) *Repository_LoadScanCache_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_LoadScanCache_Call) RunAndReturn(run func() (*models.ScanCache, error)) *Repository_LoadScanCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveScanCache provides a mock function with given fields: scanCache
+func (_m *Repository) SaveScanCache(scanCache *models.ScanCache) error {
+	ret := _m.Called(scanCache)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveScanCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ScanCache) error); ok {
+		r0 = rf(scanCache)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SaveScanCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveScanCache'
+type Repository_SaveScanCache_Call struct {
+	*mock.Call
+}
+
+// SaveScanCache is a helper method to define mock.On call
+//   - scanCache *models.ScanCache
+func (_e *Repository_Expecter) SaveScanCache(scanCache interface{}) *Repository_SaveScanCache_Call {
+	return &Repository_SaveScanCache_Call{Call: _e.mock.On("SaveScanCache", scanCache)}
+}
+
+func (_c *Repository_SaveScanCache_Call) Run(run func(scanCache *models.ScanCache)) *Repository_SaveScanCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ScanCache))
+	})
+	return _c
+}
+
+func (_c *Repository_SaveScanCache_Call) Return(_a0 error) *Repository_SaveScanCache_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SaveScanCache_Call) RunAndReturn(run func(*models.ScanCache) error) *Repository_SaveScanCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
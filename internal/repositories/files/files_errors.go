@@ -3,5 +3,6 @@ package files
 import "errors"
 
 var (
-	ErrFileNotFound = errors.New("file not found")
+	ErrFileNotFound     = errors.New("file not found")
+	ErrRevisionNotFound = errors.New("git revision not found")
 )
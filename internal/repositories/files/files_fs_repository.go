@@ -0,0 +1,138 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// Read the sources from an fs.FS, e.g. fstest.MapFS, a zip.Reader or a git snapshot,
+// and write or remove the generated files in the overlay
+type fsRepositoryImpl struct {
+	fsys    fs.FS
+	overlay Overlay
+}
+
+func NewFSRepository(fsys fs.FS, overlay Overlay) Repository {
+	return &fsRepositoryImpl{
+		fsys:    fsys,
+		overlay: overlay,
+	}
+}
+
+// fs.FS paths always use forward slashes without a leading ./
+func toFSPath(filePath string) string {
+	return filepath.ToSlash(filepath.Clean(filePath))
+}
+
+func fsError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrFileNotFound
+	}
+
+	return err
+}
+
+func (f *fsRepositoryImpl) ReadFile(filePath string) (string, error) {
+	if data, exists := f.overlay.ReadFile(filePath); exists {
+		return data, nil
+	}
+
+	data, err := fs.ReadFile(f.fsys, toFSPath(filePath))
+	if err != nil {
+		return "", fsError(err)
+	}
+
+	return string(data), nil
+}
+
+func (f *fsRepositoryImpl) ContainsAny(filePath string, markers []string) (bool, error) {
+	if data, exists := f.overlay.ReadFile(filePath); exists {
+		return containsAny(strings.NewReader(data), markers)
+	}
+
+	file, err := f.fsys.Open(toFSPath(filePath))
+	if err != nil {
+		return false, fsError(err)
+	}
+	defer file.Close()
+
+	return containsAny(file, markers)
+}
+
+func (f *fsRepositoryImpl) StatFile(filePath string) (*models.FileStat, error) {
+	if data, exists := f.overlay.ReadFile(filePath); exists {
+		return &models.FileStat{
+			Size: int64(len(data)),
+		}, nil
+	}
+
+	info, err := fs.Stat(f.fsys, toFSPath(filePath))
+	if err != nil {
+		return nil, fsError(err)
+	}
+
+	return &models.FileStat{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+func (f *fsRepositoryImpl) GetGoModFile() (string, error) {
+	return f.ReadFile("go.mod")
+}
+
+func (f *fsRepositoryImpl) GetConfigFile() (string, error) {
+	return f.ReadFile(CONFIG_FILE)
+}
+
+func (f *fsRepositoryImpl) ListAllGoFiles(ctx context.Context, jobs int) ([]string, error) {
+	goFiles, err := listGoFiles(ctx, jobs, func(directory string) ([]fs.DirEntry, error) {
+		return fs.ReadDir(f.fsys, toFSPath(directory))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Files that only exist in the overlay are listed after the tree, like files created after the walk
+	overlayFiles := make([]string, 0)
+	for _, filePath := range f.overlay.ListFiles() {
+		if filepath.Ext(filePath) == ".go" && !slices.Contains(goFiles, filePath) {
+			overlayFiles = append(overlayFiles, filePath)
+		}
+	}
+	slices.Sort(overlayFiles)
+
+	return append(goFiles, overlayFiles...), nil
+}
+
+func (f *fsRepositoryImpl) WriteFile(directory string, fileName string, data string) error {
+	return f.overlay.WriteFile(filepath.Join(directory, fileName), data)
+}
+
+func (f *fsRepositoryImpl) ListFileNames(directory string) ([]string, error) {
+	entries, err := fs.ReadDir(f.fsys, toFSPath(directory))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	names := fileNames(entries)
+	for _, filePath := range f.overlay.ListFiles() {
+		if filepath.Dir(filePath) == filepath.Clean(directory) && !slices.Contains(names, filepath.Base(filePath)) {
+			names = append(names, filepath.Base(filePath))
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Only the overlay is changed, a file of the fs.FS is never removed
+func (f *fsRepositoryImpl) RemoveFile(directory string, fileName string) error {
+	return f.overlay.RemoveFile(filepath.Join(directory, fileName))
+}
@@ -0,0 +1,104 @@
+package files
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFSRepository(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"go.mod":                     {Data: []byte("module github.com/foo/bar\n")},
+		"main.go":                    {Data: []byte("package main\n")},
+		"internal/service/a.go":      {Data: []byte("package service\n\n// @WireSet(\"Service\")\nfunc NewA() {}\n")},
+		"internal/service/README.md": {Data: []byte("# service\n")},
+		"wire/wire.go":               {Data: []byte("//go:build wireinject\n\npackage wire\n")},
+	}
+
+	overlay := NewMemoryOverlay()
+	repository := NewFSRepository(fsys, overlay)
+
+	goMod, err := repository.GetGoModFile()
+	assert.NoError(t, err)
+	assert.Equal(t, "module github.com/foo/bar\n", goMod)
+
+	goFiles, err := repository.ListAllGoFiles(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main.go", filepath.Join("wire", "wire.go"), filepath.Join("internal", "service", "a.go")}, goFiles)
+
+	matched, err := repository.ContainsAny(filepath.Join("internal", "service", "a.go"), []string{"@WireSet("})
+	assert.NoError(t, err)
+	assert.True(t, matched)
+
+	_, err = repository.ReadFile("missing.go")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+
+	// Written files shadow the fs.FS and show up in the listing
+	assert.NoError(t, repository.WriteFile("wire", "wire_set_gen.go", "package wire\n"))
+	assert.NoError(t, repository.WriteFile(".", "main.go", "package main\n\nfunc main() {}\n"))
+
+	data, err := repository.ReadFile(filepath.Join("wire", "wire_set_gen.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package wire\n", data)
+
+	data, err = repository.ReadFile("main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", data)
+
+	stat, err := repository.StatFile("main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), stat.Size)
+
+	goFiles, err = repository.ListAllGoFiles(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main.go", filepath.Join("wire", "wire.go"), filepath.Join("internal", "service", "a.go"), filepath.Join("wire", "wire_set_gen.go")}, goFiles)
+
+	assert.Equal(t, map[string]string{
+		"main.go":                                "package main\n\nfunc main() {}\n",
+		filepath.Join("wire", "wire_set_gen.go"): "package wire\n",
+	}, overlay.Files())
+
+	fileNames, err := repository.ListFileNames("wire")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wire.go", "wire_set_gen.go"}, fileNames)
+
+	// Only the overlay loses the removed files
+	assert.NoError(t, repository.RemoveFile("wire", "wire_set_gen.go"))
+	assert.NoError(t, repository.RemoveFile("wire", "wire.go"))
+
+	fileNames, err = repository.ListFileNames("wire")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wire.go"}, fileNames)
+
+	// The fs.FS itself is never modified
+	assert.Equal(t, "package main\n", string(fsys["main.go"].Data))
+}
+
+func TestNewMapOverlay(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{"./wire/wire.go": "package wire\n"}
+	overlay := NewMapOverlay(files)
+
+	data, exists := overlay.ReadFile(filepath.Join("wire", "wire.go"))
+	assert.True(t, exists)
+	assert.Equal(t, "package wire\n", data)
+
+	// The keys of the caller are kept, a write to the same file goes to its key
+	assert.NoError(t, overlay.WriteFile(filepath.Join("wire", "wire.go"), "package app\n"))
+	assert.NoError(t, overlay.WriteFile(filepath.Join("wire", "wire_set_gen.go"), "package app\n"))
+
+	assert.Equal(t, map[string]string{
+		"./wire/wire.go":                         "package app\n",
+		filepath.Join("wire", "wire_set_gen.go"): "package app\n",
+	}, files)
+	assert.ElementsMatch(t, []string{filepath.Join("wire", "wire.go"), filepath.Join("wire", "wire_set_gen.go")}, overlay.ListFiles())
+
+	assert.NoError(t, overlay.RemoveFile("wire/./wire.go"))
+	assert.Equal(t, map[string]string{filepath.Join("wire", "wire_set_gen.go"): "package app\n"}, files)
+}
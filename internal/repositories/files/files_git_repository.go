@@ -0,0 +1,288 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Read the module at a git revision through the local git plumbing, without checking it out
+// The git commands are stopped when ctx is done
+func NewGitRepository(ctx context.Context, baseDir string, rev string, overlay Overlay) Repository {
+	return NewFSRepository(NewGitFS(ctx, baseDir, rev), overlay)
+}
+
+// gitFS is a read only fs.FS over the go.mod, config and .go files of a git revision.
+// The tree is loaded on first use with one ls-tree and one cat-file --batch call.
+// fs.FS has no context, so the one of the caller is kept for the git commands.
+type gitFS struct {
+	ctx     context.Context
+	baseDir string
+	rev     string
+
+	once  sync.Once
+	err   error
+	files map[string][]byte
+	dirs  map[string][]fs.DirEntry
+}
+
+func NewGitFS(ctx context.Context, baseDir string, rev string) fs.FS {
+	return &gitFS{
+		ctx:     ctx,
+		baseDir: baseDir,
+		rev:     rev,
+	}
+}
+
+type gitBlob struct {
+	path string
+	hash string
+}
+
+func (g *gitFS) git(stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(g.ctx, "git", append([]string{"-C", g.baseDir}, args...)...)
+	cmd.Stdin = stdin
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if ctxErr := g.ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("git %s: %w", args[0], ctxErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+func (g *gitFS) load() error {
+	g.once.Do(func() {
+		if _, err := g.git(nil, "rev-parse", "--verify", "--quiet", g.rev+"^{commit}"); err != nil {
+			g.err = fmt.Errorf("%w: %s", ErrRevisionNotFound, g.rev)
+			if g.ctx.Err() != nil {
+				g.err = err
+			}
+			return
+		}
+
+		// Paths are relative to the base directory, the listing is limited to it
+		output, err := g.git(nil, "ls-tree", "-r", "-z", g.rev, "--", ".")
+		if err != nil {
+			g.err = err
+			return
+		}
+
+		blobs := make([]gitBlob, 0)
+		for _, entry := range strings.Split(string(output), "\x00") {
+			// <mode> SP <type> SP <object> TAB <file>
+			meta, filePath, found := strings.Cut(entry, "\t")
+			fields := strings.Fields(meta)
+			if !found || len(fields) != 3 || fields[1] != "blob" {
+				continue
+			}
+
+			if filePath == "go.mod" || filePath == CONFIG_FILE || path.Ext(filePath) == ".go" {
+				blobs = append(blobs, gitBlob{path: filePath, hash: fields[2]})
+			}
+		}
+
+		g.files, g.err = g.readBlobs(blobs)
+		if g.err != nil {
+			return
+		}
+
+		g.dirs = buildDirEntries(g.files)
+	})
+
+	return g.err
+}
+
+// For read every blob content with a single cat-file --batch call
+func (g *gitFS) readBlobs(blobs []gitBlob) (map[string][]byte, error) {
+	var stdin bytes.Buffer
+	for _, blob := range blobs {
+		stdin.WriteString(blob.hash + "\n")
+	}
+
+	output, err := g.git(&stdin, "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(blobs))
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for _, blob := range blobs {
+		// <object> SP <type> SP <size> LF <contents> LF
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected cat-file output for %s: %q", blob.path, header)
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+
+		files[blob.path] = data[:size]
+	}
+
+	return files, nil
+}
+
+// For create the directory listing of every parent directory of the files
+func buildDirEntries(files map[string][]byte) map[string][]fs.DirEntry {
+	children := map[string]map[string]fs.FileInfo{".": {}}
+
+	for filePath, data := range files {
+		name := path.Base(filePath)
+		info := fs.FileInfo(&gitFileInfo{name: name, size: int64(len(data))})
+
+		for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+			if children[dir] == nil {
+				children[dir] = make(map[string]fs.FileInfo)
+			}
+			children[dir][name] = info
+
+			if dir == "." {
+				break
+			}
+
+			name = path.Base(dir)
+			info = &gitFileInfo{name: name, dir: true}
+		}
+	}
+
+	dirs := make(map[string][]fs.DirEntry, len(children))
+	for dir, infos := range children {
+		entries := make([]fs.DirEntry, 0, len(infos))
+		for _, info := range infos {
+			entries = append(entries, fs.FileInfoToDirEntry(info))
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name() < entries[j].Name()
+		})
+
+		dirs[dir] = entries
+	}
+
+	return dirs
+}
+
+func (g *gitFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if err := g.load(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if data, exists := g.files[name]; exists {
+		return &gitFile{
+			Reader: bytes.NewReader(data),
+			info:   &gitFileInfo{name: path.Base(name), size: int64(len(data))},
+		}, nil
+	}
+
+	if entries, exists := g.dirs[name]; exists {
+		return &gitDir{
+			info:    &gitFileInfo{name: path.Base(name), dir: true},
+			entries: entries,
+		}, nil
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (g *gitFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := g.load(); err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries, exists := g.dirs[name]
+	if !exists {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return append([]fs.DirEntry(nil), entries...), nil
+}
+
+type gitFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i *gitFileInfo) Name() string       { return i.name }
+func (i *gitFileInfo) Size() int64        { return i.size }
+func (i *gitFileInfo) ModTime() time.Time { return time.Time{} }
+func (i *gitFileInfo) IsDir() bool        { return i.dir }
+func (i *gitFileInfo) Sys() any           { return nil }
+
+func (i *gitFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+
+	return 0444
+}
+
+type gitFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *gitFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *gitFile) Close() error               { return nil }
+
+type gitDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *gitDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *gitDir) Close() error               { return nil }
+
+func (d *gitDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+func (d *gitDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	n = min(n, len(remaining))
+	d.offset += n
+
+	return remaining[:n], nil
+}
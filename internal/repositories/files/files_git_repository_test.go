@@ -0,0 +1,111 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// For create a git repository with one commit per snapshot, return the directory
+func createGitRepository(t *testing.T, snapshots ...map[string]string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+
+	run("init", "-q")
+	for i, snapshot := range snapshots {
+		for filePath, content := range snapshot {
+			fullPath := filepath.Join(dir, filePath)
+			assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), os.ModePerm))
+			assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+		}
+
+		run("add", "-A")
+		run("commit", "-q", "-m", "snapshot")
+		run("tag", fmt.Sprintf("v%d", i+1))
+	}
+
+	return dir
+}
+
+func TestGitFS(t *testing.T) {
+	t.Parallel()
+
+	dir := createGitRepository(t,
+		map[string]string{
+			"go.mod":                "module github.com/foo/bar\n",
+			"README.md":             "# bar\n",
+			"internal/service/a.go": "package service\n",
+		},
+		map[string]string{
+			"internal/service/a.go": "package service\n\nfunc A() {}\n",
+			"wire/wire.go":          "//go:build wireinject\n\npackage wire\n",
+		},
+	)
+
+	fsys := NewGitFS(context.Background(), dir, "v1")
+	assert.NoError(t, fstest.TestFS(fsys, "go.mod", "internal/service/a.go"))
+
+	// Other files than go.mod and .go files are not loaded
+	_, err := fsys.Open("README.md")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	repository := NewGitRepository(context.Background(), dir, "v1", NewMemoryOverlay())
+	goFiles, err := repository.ListAllGoFiles(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("internal", "service", "a.go")}, goFiles)
+
+	// The working tree and other revisions are not affected
+	repository = NewGitRepository(context.Background(), dir, "v2", NewMemoryOverlay())
+	data, err := repository.ReadFile(filepath.Join("internal", "service", "a.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package service\n\nfunc A() {}\n", data)
+
+	goFiles, err = repository.ListAllGoFiles(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("wire", "wire.go"), filepath.Join("internal", "service", "a.go")}, goFiles)
+}
+
+func TestGitFS_unknownRevision(t *testing.T) {
+	t.Parallel()
+
+	dir := createGitRepository(t, map[string]string{"go.mod": "module github.com/foo/bar\n"})
+
+	_, err := NewGitRepository(context.Background(), dir, "does-not-exist", NewMemoryOverlay()).GetGoModFile()
+
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+}
+
+func TestGitFS_cancelled(t *testing.T) {
+	t.Parallel()
+
+	dir := createGitRepository(t, map[string]string{"go.mod": "module github.com/foo/bar\n"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewGitRepository(ctx, dir, "v1", NewMemoryOverlay()).GetGoModFile()
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrRevisionNotFound)
+}
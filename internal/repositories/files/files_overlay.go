@@ -0,0 +1,114 @@
+package files
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// Overlay receives the files written through an fs.FS backed repository
+// and shadows the files of the fs.FS with the same path
+type Overlay interface {
+	ReadFile(filePath string) (string, bool)
+	WriteFile(filePath string, data string) error
+	RemoveFile(filePath string) error
+	ListFiles() []string
+}
+
+type MemoryOverlay struct {
+	mu    sync.RWMutex
+	files map[string]string
+
+	// The key of files for every cleaned path, the keys of the caller are kept as they are
+	keys map[string]string
+}
+
+func NewMemoryOverlay() *MemoryOverlay {
+	return NewMapOverlay(nil)
+}
+
+// NewMapOverlay shadows the fs.FS with the given files and writes to the same map,
+// so the caller can read the written files back, the paths are cleaned when they are looked up
+func NewMapOverlay(files map[string]string) *MemoryOverlay {
+	if files == nil {
+		files = make(map[string]string)
+	}
+
+	keys := make(map[string]string, len(files))
+	for filePath := range files {
+		// The clean path wins when the caller has the same file under several spellings
+		cleanPath := filepath.Clean(filePath)
+		if _, exists := keys[cleanPath]; !exists || filePath == cleanPath {
+			keys[cleanPath] = filePath
+		}
+	}
+
+	return &MemoryOverlay{
+		files: files,
+		keys:  keys,
+	}
+}
+
+func (o *MemoryOverlay) ReadFile(filePath string) (string, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	key, exists := o.keys[filepath.Clean(filePath)]
+	if !exists {
+		return "", false
+	}
+
+	return o.files[key], true
+}
+
+func (o *MemoryOverlay) WriteFile(filePath string, data string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cleanPath := filepath.Clean(filePath)
+	key, exists := o.keys[cleanPath]
+	if !exists {
+		key = cleanPath
+		o.keys[cleanPath] = key
+	}
+
+	o.files[key] = data
+	return nil
+}
+
+func (o *MemoryOverlay) RemoveFile(filePath string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cleanPath := filepath.Clean(filePath)
+	if key, exists := o.keys[cleanPath]; exists {
+		delete(o.files, key)
+		delete(o.keys, cleanPath)
+	}
+
+	return nil
+}
+
+func (o *MemoryOverlay) ListFiles() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	filePaths := make([]string, 0, len(o.keys))
+	for filePath := range o.keys {
+		filePaths = append(filePaths, filePath)
+	}
+
+	return filePaths
+}
+
+// Files returns a copy of every written file keyed by cleaned path
+func (o *MemoryOverlay) Files() map[string]string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	files := make(map[string]string, len(o.keys))
+	for filePath, key := range o.keys {
+		files[filePath] = o.files[key]
+	}
+
+	return files
+}
@@ -1,27 +1,61 @@
 package files
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/pkg/utils"
 )
 
-const BASE_DIR = "."
+const (
+	BASE_DIR    = "."
+	CHUNK_SIZE  = 32 * 1024
+	CONFIG_FILE = ".wiresetgen.yaml"
+)
 
 type Repository interface {
 	GetGoModFile() (string, error)
-	ListAllGoFiles() ([]string, error)
+	GetConfigFile() (string, error)
+	ListAllGoFiles(ctx context.Context, jobs int) ([]string, error)
 	ReadFile(filePath string) (string, error)
+	ContainsAny(filePath string, markers []string) (bool, error)
+	StatFile(filePath string) (*models.FileStat, error)
 	WriteFile(directory string, fileName string, data string) error
+	ListFileNames(directory string) ([]string, error)
+	RemoveFile(directory string, fileName string) error
+}
+
+// Reuse the read buffers, most files are checked and dropped right away
+var chunkPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, CHUNK_SIZE)
+		return &buf
+	},
 }
 
-type repositoryImpl struct{}
+// Paths given to and returned by the repository are relative to the base directory
+type repositoryImpl struct {
+	baseDir string
+}
 
-func NewFileRepository() Repository {
-	return &repositoryImpl{}
+func NewFileRepository(baseDir string) Repository {
+	return &repositoryImpl{
+		baseDir: baseDir,
+	}
+}
+
+func (f *repositoryImpl) resolve(filePath string) string {
+	return filepath.Join(f.baseDir, filePath)
 }
 
 func (f *repositoryImpl) ReadFile(filePath string) (string, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := os.ReadFile(f.resolve(filePath))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", ErrFileNotFound
@@ -32,34 +66,47 @@ func (f *repositoryImpl) ReadFile(filePath string) (string, error) {
 	return string(data), nil
 }
 
-func (f *repositoryImpl) GetGoModFile() (string, error) {
-	return f.ReadFile(filepath.Join(BASE_DIR, "go.mod"))
+// Read the file in chunks and stop at the first marker, so files without any marker are never held in memory
+func (f *repositoryImpl) ContainsAny(filePath string, markers []string) (bool, error) {
+	file, err := os.Open(f.resolve(filePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, ErrFileNotFound
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	return containsAny(file, markers)
 }
 
-func (f *repositoryImpl) ListAllGoFiles() ([]string, error) {
-	pendingDirectory := []string{BASE_DIR}
-	goFiles := make([]string, 0)
+func (f *repositoryImpl) StatFile(filePath string) (*models.FileStat, error) {
+	info, err := os.Stat(f.resolve(filePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
 
-	for len(pendingDirectory) > 0 {
-		currentDir := pendingDirectory[0]
-		pendingDirectory = pendingDirectory[1:]
+	return &models.FileStat{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
 
-		files, err := os.ReadDir(currentDir)
-		if err != nil {
-			return nil, err
-		}
+func (f *repositoryImpl) GetGoModFile() (string, error) {
+	return f.ReadFile("go.mod")
+}
 
-		for _, file := range files {
-			filePath := filepath.Join(currentDir, file.Name())
-			if file.IsDir() {
-				pendingDirectory = append(pendingDirectory, filePath)
-			} else if filepath.Ext(file.Name()) == ".go" {
-				goFiles = append(goFiles, filePath)
-			}
-		}
-	}
+func (f *repositoryImpl) GetConfigFile() (string, error) {
+	return f.ReadFile(CONFIG_FILE)
+}
 
-	return goFiles, nil
+func (f *repositoryImpl) ListAllGoFiles(ctx context.Context, jobs int) ([]string, error) {
+	return listGoFiles(ctx, jobs, func(directory string) ([]fs.DirEntry, error) {
+		return os.ReadDir(f.resolve(directory))
+	})
 }
 
 func (f *repositoryImpl) WriteFile(directory string, fileName string, data string) error {
@@ -67,11 +114,130 @@ func (f *repositoryImpl) WriteFile(directory string, fileName string, data strin
 	dataBytes := []byte(data)
 
 	// Create the directory if it doesn't exist
-	if err := os.MkdirAll(directory, os.ModePerm); err != nil {
+	if err := os.MkdirAll(f.resolve(directory), os.ModePerm); err != nil {
 		return err
 	}
 	// Create the file path
-	filePath := filepath.Join(directory, fileName)
+	filePath := f.resolve(filepath.Join(directory, fileName))
 
 	return os.WriteFile(filePath, dataBytes, 0644)
 }
+
+// For list the names of the files in the directory, a missing directory has no files
+func (f *repositoryImpl) ListFileNames(directory string) ([]string, error) {
+	entries, err := os.ReadDir(f.resolve(directory))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	return fileNames(entries), nil
+}
+
+// A file that is already gone is not an error
+func (f *repositoryImpl) RemoveFile(directory string, fileName string) error {
+	err := os.Remove(f.resolve(filepath.Join(directory, fileName)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func fileNames(entries []fs.DirEntry) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names
+}
+
+// For read the reader in chunks and stop at the first marker
+func containsAny(reader io.Reader, markers []string) (bool, error) {
+	// Keep the tail of the previous chunk, so a marker split between two chunks is still found
+	overlap := 0
+	byteMarkers := make([][]byte, 0, len(markers))
+	for _, marker := range markers {
+		byteMarkers = append(byteMarkers, []byte(marker))
+		overlap = max(overlap, len(marker)-1)
+	}
+
+	bufPointer := chunkPool.Get().(*[]byte)
+	defer chunkPool.Put(bufPointer)
+
+	if cap(*bufPointer) < overlap+CHUNK_SIZE {
+		*bufPointer = make([]byte, overlap+CHUNK_SIZE)
+	}
+	buf := (*bufPointer)[:overlap+CHUNK_SIZE]
+
+	kept := 0
+	for {
+		n, err := io.ReadFull(reader, buf[kept:])
+		window := buf[:kept+n]
+
+		for _, marker := range byteMarkers {
+			if bytes.Contains(window, marker) {
+				return true, nil
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		kept = min(overlap, len(window))
+		copy(buf, window[len(window)-kept:])
+	}
+}
+
+// For walk the tree breadth first from the base directory, the paths are relative to it
+func listGoFiles(ctx context.Context, jobs int, readDir func(directory string) ([]fs.DirEntry, error)) ([]string, error) {
+	pendingDirectory := []string{"."}
+	goFiles := make([]string, 0)
+
+	// Read one level of directories at a time, merging in the directory order
+	// keeps the result the same as a sequential breadth first walk
+	for len(pendingDirectory) > 0 {
+		levelFiles := make([][]string, len(pendingDirectory))
+		levelDirectory := make([][]string, len(pendingDirectory))
+
+		err := utils.ForEachParallel(ctx, jobs, len(pendingDirectory), func(ctx context.Context, i int) error {
+			currentDir := pendingDirectory[i]
+
+			files, err := readDir(currentDir)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range files {
+				filePath := filepath.Join(currentDir, file.Name())
+				if file.IsDir() {
+					levelDirectory[i] = append(levelDirectory[i], filePath)
+				} else if filepath.Ext(file.Name()) == ".go" {
+					levelFiles[i] = append(levelFiles[i], filePath)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		pendingDirectory = make([]string, 0)
+		for i := range levelFiles {
+			goFiles = append(goFiles, levelFiles[i]...)
+			pendingDirectory = append(pendingDirectory, levelDirectory[i]...)
+		}
+	}
+
+	return goFiles, nil
+}
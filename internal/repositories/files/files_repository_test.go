@@ -0,0 +1,101 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsAny(t *testing.T) {
+	t.Parallel()
+
+	markers := []string{"@WireSet(", "wireinject"}
+
+	testCases := []struct {
+		name        string
+		fileContent string
+		expected    bool
+	}{
+		{
+			name:        "Marker in small file",
+			fileContent: "package service\n\n// @WireSet(\"Service\")\nfunc NewService() {}\n",
+			expected:    true,
+		},
+		{
+			name:        "No marker",
+			fileContent: "package service\n\nfunc NewService() {}\n",
+			expected:    false,
+		},
+		{
+			name:        "Empty file",
+			fileContent: "",
+			expected:    false,
+		},
+		{
+			name:        "Marker split between chunks",
+			fileContent: strings.Repeat("a", CHUNK_SIZE-3) + "//go:build wireinject\n",
+			expected:    true,
+		},
+		{
+			name:        "Marker after many chunks",
+			fileContent: strings.Repeat("// filler line\n", 3*CHUNK_SIZE/15) + "// @WireSet(\"Service\")\n",
+			expected:    true,
+		},
+		{
+			name:        "Large file without marker",
+			fileContent: strings.Repeat("// filler line\n", 3*CHUNK_SIZE/15),
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			baseDir := tt.TempDir()
+			assert.NoError(tt, os.WriteFile(filepath.Join(baseDir, "file.go"), []byte(tc.fileContent), 0644))
+
+			matched, err := NewFileRepository(baseDir).ContainsAny("file.go", markers)
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.expected, matched)
+		})
+	}
+}
+
+func TestContainsAny_fileNotFound(t *testing.T) {
+	t.Parallel()
+
+	matched, err := NewFileRepository(t.TempDir()).ContainsAny("missing.go", []string{"wireinject"})
+
+	assert.False(t, matched)
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
+
+func TestRemoveFile(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+	repository := NewFileRepository(baseDir)
+	assert.NoError(t, repository.WriteFile("wire", "wire_set_gen.go", "package wire\n"))
+	assert.NoError(t, repository.WriteFile("wire", "wire.go", "package wire\n"))
+
+	fileNames, err := repository.ListFileNames("wire")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wire.go", "wire_set_gen.go"}, fileNames)
+
+	assert.NoError(t, repository.RemoveFile("wire", "wire_set_gen.go"))
+	// Removing a missing file is not an error
+	assert.NoError(t, repository.RemoveFile("wire", "wire_set_gen.go"))
+
+	fileNames, err = repository.ListFileNames("wire")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wire.go"}, fileNames)
+
+	fileNames, err = repository.ListFileNames("missing")
+	assert.NoError(t, err)
+	assert.Empty(t, fileNames)
+}
@@ -0,0 +1,228 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_files
+
+import mock "github.com/stretchr/testify/mock"
+
+// Overlay is an autogenerated mock type for the Overlay type
+type Overlay struct {
+	mock.Mock
+}
+
+type Overlay_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Overlay) EXPECT() *Overlay_Expecter {
+	return &Overlay_Expecter{mock: &_m.Mock}
+}
+
+// ListFiles provides a mock function with no fields
+func (_m *Overlay) ListFiles() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFiles")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Overlay_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type Overlay_ListFiles_Call struct {
+	*mock.Call
+}
+
+// ListFiles is a helper method to define mock.On call
+func (_e *Overlay_Expecter) ListFiles() *Overlay_ListFiles_Call {
+	return &Overlay_ListFiles_Call{Call: _e.mock.On("ListFiles")}
+}
+
+func (_c *Overlay_ListFiles_Call) Run(run func()) *Overlay_ListFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Overlay_ListFiles_Call) Return(_a0 []string) *Overlay_ListFiles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Overlay_ListFiles_Call) RunAndReturn(run func() []string) *Overlay_ListFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadFile provides a mock function with given fields: filePath
+func (_m *Overlay) ReadFile(filePath string) (string, bool) {
+	ret := _m.Called(filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadFile")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (string, bool)); ok {
+		return rf(filePath)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(filePath)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(filePath)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Overlay_ReadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadFile'
+type Overlay_ReadFile_Call struct {
+	*mock.Call
+}
+
+// ReadFile is a helper method to define mock.On call
+//   - filePath string
+func (_e *Overlay_Expecter) ReadFile(filePath interface{}) *Overlay_ReadFile_Call {
+	return &Overlay_ReadFile_Call{Call: _e.mock.On("ReadFile", filePath)}
+}
+
+func (_c *Overlay_ReadFile_Call) Run(run func(filePath string)) *Overlay_ReadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Overlay_ReadFile_Call) Return(_a0 string, _a1 bool) *Overlay_ReadFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Overlay_ReadFile_Call) RunAndReturn(run func(string) (string, bool)) *Overlay_ReadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveFile provides a mock function with given fields: filePath
+func (_m *Overlay) RemoveFile(filePath string) error {
+	ret := _m.Called(filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(filePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Overlay_RemoveFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFile'
+type Overlay_RemoveFile_Call struct {
+	*mock.Call
+}
+
+// RemoveFile is a helper method to define mock.On call
+//   - filePath string
+func (_e *Overlay_Expecter) RemoveFile(filePath interface{}) *Overlay_RemoveFile_Call {
+	return &Overlay_RemoveFile_Call{Call: _e.mock.On("RemoveFile", filePath)}
+}
+
+func (_c *Overlay_RemoveFile_Call) Run(run func(filePath string)) *Overlay_RemoveFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Overlay_RemoveFile_Call) Return(_a0 error) *Overlay_RemoveFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Overlay_RemoveFile_Call) RunAndReturn(run func(string) error) *Overlay_RemoveFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteFile provides a mock function with given fields: filePath, data
+func (_m *Overlay) WriteFile(filePath string, data string) error {
+	ret := _m.Called(filePath, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(filePath, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Overlay_WriteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteFile'
+type Overlay_WriteFile_Call struct {
+	*mock.Call
+}
+
+// WriteFile is a helper method to define mock.On call
+//   - filePath string
+//   - data string
+func (_e *Overlay_Expecter) WriteFile(filePath interface{}, data interface{}) *Overlay_WriteFile_Call {
+	return &Overlay_WriteFile_Call{Call: _e.mock.On("WriteFile", filePath, data)}
+}
+
+func (_c *Overlay_WriteFile_Call) Run(run func(filePath string, data string)) *Overlay_WriteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Overlay_WriteFile_Call) Return(_a0 error) *Overlay_WriteFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Overlay_WriteFile_Call) RunAndReturn(run func(string, string) error) *Overlay_WriteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOverlay creates a new instance of Overlay. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOverlay(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Overlay {
+	mock := &Overlay{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
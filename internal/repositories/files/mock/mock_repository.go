@@ -2,7 +2,13 @@
 
 package mock_files
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
 
 // Repository is an autogenerated mock type for the Repository type
 type Repository struct {
@@ -17,6 +23,118 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// ContainsAny provides a mock function with given fields: filePath, markers
+func (_m *Repository) ContainsAny(filePath string, markers []string) (bool, error) {
+	ret := _m.Called(filePath, markers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainsAny")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) (bool, error)); ok {
+		return rf(filePath, markers)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) bool); ok {
+		r0 = rf(filePath, markers)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(filePath, markers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ContainsAny_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContainsAny'
+type Repository_ContainsAny_Call struct {
+	*mock.Call
+}
+
+// ContainsAny is a helper method to define mock.On call
+//   - filePath string
+//   - markers []string
+func (_e *Repository_Expecter) ContainsAny(filePath interface{}, markers interface{}) *Repository_ContainsAny_Call {
+	return &Repository_ContainsAny_Call{Call: _e.mock.On("ContainsAny", filePath, markers)}
+}
+
+func (_c *Repository_ContainsAny_Call) Run(run func(filePath string, markers []string)) *Repository_ContainsAny_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_ContainsAny_Call) Return(_a0 bool, _a1 error) *Repository_ContainsAny_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ContainsAny_Call) RunAndReturn(run func(string, []string) (bool, error)) *Repository_ContainsAny_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfigFile provides a mock function with no fields
+func (_m *Repository) GetConfigFile() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigFile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetConfigFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfigFile'
+type Repository_GetConfigFile_Call struct {
+	*mock.Call
+}
+
+// GetConfigFile is a helper method to define mock.On call
+func (_e *Repository_Expecter) GetConfigFile() *Repository_GetConfigFile_Call {
+	return &Repository_GetConfigFile_Call{Call: _e.mock.On("GetConfigFile")}
+}
+
+func (_c *Repository_GetConfigFile_Call) Run(run func()) *Repository_GetConfigFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Repository_GetConfigFile_Call) Return(_a0 string, _a1 error) *Repository_GetConfigFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetConfigFile_Call) RunAndReturn(run func() (string, error)) *Repository_GetConfigFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetGoModFile provides a mock function with no fields
 func (_m *Repository) GetGoModFile() (string, error) {
 	ret := _m.Called()
@@ -72,9 +190,9 @@ func (_c *Repository_GetGoModFile_Call) RunAndReturn(run func() (string, error))
 	return _c
 }
 
-// ListAllGoFiles provides a mock function with no fields
-func (_m *Repository) ListAllGoFiles() ([]string, error) {
-	ret := _m.Called()
+// ListAllGoFiles provides a mock function with given fields: ctx, jobs
+func (_m *Repository) ListAllGoFiles(ctx context.Context, jobs int) ([]string, error) {
+	ret := _m.Called(ctx, jobs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListAllGoFiles")
@@ -82,19 +200,19 @@ func (_m *Repository) ListAllGoFiles() ([]string, error) {
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, jobs)
 	}
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, jobs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,13 +226,15 @@ type Repository_ListAllGoFiles_Call struct {
 }
 
 // ListAllGoFiles is a helper method to define mock.On call
-func (_e *Repository_Expecter) ListAllGoFiles() *Repository_ListAllGoFiles_Call {
-	return &Repository_ListAllGoFiles_Call{Call: _e.mock.On("ListAllGoFiles")}
+//   - ctx context.Context
+//   - jobs int
+func (_e *Repository_Expecter) ListAllGoFiles(ctx interface{}, jobs interface{}) *Repository_ListAllGoFiles_Call {
+	return &Repository_ListAllGoFiles_Call{Call: _e.mock.On("ListAllGoFiles", ctx, jobs)}
 }
 
-func (_c *Repository_ListAllGoFiles_Call) Run(run func()) *Repository_ListAllGoFiles_Call {
+func (_c *Repository_ListAllGoFiles_Call) Run(run func(ctx context.Context, jobs int)) *Repository_ListAllGoFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -124,7 +244,65 @@ func (_c *Repository_ListAllGoFiles_Call) Return(_a0 []string, _a1 error) *Repos
 	return _c
 }
 
-func (_c *Repository_ListAllGoFiles_Call) RunAndReturn(run func() ([]string, error)) *Repository_ListAllGoFiles_Call {
+func (_c *Repository_ListAllGoFiles_Call) RunAndReturn(run func(context.Context, int) ([]string, error)) *Repository_ListAllGoFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFileNames provides a mock function with given fields: directory
+func (_m *Repository) ListFileNames(directory string) ([]string, error) {
+	ret := _m.Called(directory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFileNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(directory)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(directory)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(directory)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListFileNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFileNames'
+type Repository_ListFileNames_Call struct {
+	*mock.Call
+}
+
+// ListFileNames is a helper method to define mock.On call
+//   - directory string
+func (_e *Repository_Expecter) ListFileNames(directory interface{}) *Repository_ListFileNames_Call {
+	return &Repository_ListFileNames_Call{Call: _e.mock.On("ListFileNames", directory)}
+}
+
+func (_c *Repository_ListFileNames_Call) Run(run func(directory string)) *Repository_ListFileNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListFileNames_Call) Return(_a0 []string, _a1 error) *Repository_ListFileNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListFileNames_Call) RunAndReturn(run func(string) ([]string, error)) *Repository_ListFileNames_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -185,6 +363,111 @@ func (_c *Repository_ReadFile_Call) RunAndReturn(run func(string) (string, error
 	return _c
 }
 
+// RemoveFile provides a mock function with given fields: directory, fileName
+func (_m *Repository) RemoveFile(directory string, fileName string) error {
+	ret := _m.Called(directory, fileName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(directory, fileName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RemoveFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFile'
+type Repository_RemoveFile_Call struct {
+	*mock.Call
+}
+
+// RemoveFile is a helper method to define mock.On call
+//   - directory string
+//   - fileName string
+func (_e *Repository_Expecter) RemoveFile(directory interface{}, fileName interface{}) *Repository_RemoveFile_Call {
+	return &Repository_RemoveFile_Call{Call: _e.mock.On("RemoveFile", directory, fileName)}
+}
+
+func (_c *Repository_RemoveFile_Call) Run(run func(directory string, fileName string)) *Repository_RemoveFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveFile_Call) Return(_a0 error) *Repository_RemoveFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_RemoveFile_Call) RunAndReturn(run func(string, string) error) *Repository_RemoveFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StatFile provides a mock function with given fields: filePath
+func (_m *Repository) StatFile(filePath string) (*models.FileStat, error) {
+	ret := _m.Called(filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatFile")
+	}
+
+	var r0 *models.FileStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.FileStat, error)); ok {
+		return rf(filePath)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.FileStat); ok {
+		r0 = rf(filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FileStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_StatFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatFile'
+type Repository_StatFile_Call struct {
+	*mock.Call
+}
+
+// StatFile is a helper method to define mock.On call
+//   - filePath string
+func (_e *Repository_Expecter) StatFile(filePath interface{}) *Repository_StatFile_Call {
+	return &Repository_StatFile_Call{Call: _e.mock.On("StatFile", filePath)}
+}
+
+func (_c *Repository_StatFile_Call) Run(run func(filePath string)) *Repository_StatFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_StatFile_Call) Return(_a0 *models.FileStat, _a1 error) *Repository_StatFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_StatFile_Call) RunAndReturn(run func(string) (*models.FileStat, error)) *Repository_StatFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WriteFile provides a mock function with given fields: directory, fileName, data
 func (_m *Repository) WriteFile(directory string, fileName string, data string) error {
 	ret := _m.Called(directory, fileName, data)
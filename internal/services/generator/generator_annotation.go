@@ -0,0 +1,618 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// Annotations and directives share one grammar, only the marker and the keys differ:
+//
+//	annotation = marker "(" [ argument { "," argument } [ "," ] ] ")" .
+//	directive  = marker { argument } .
+//	argument   = [ key "=" ] value .
+//	key        = identifier .
+//	value      = string | word .
+//
+// A string is a Go string literal, "..." or `...`, and a word is a run of letters, digits, _, . and -
+// An annotation only takes strings, a directive takes both, the text after the ) of an annotation is not read
+
+// An error of an annotation or a directive at the 1-based column of the token it was found at
+type AnnotationError struct {
+	Column int
+	Err    error
+}
+
+func (e *AnnotationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AnnotationError) Unwrap() error {
+	return e.Err
+}
+
+// For create an error at a column, the kind is the sentinel error it wraps
+func annotationErrorf(column int, kind error, format string, args ...any) *AnnotationError {
+	return &AnnotationError{
+		Column: column,
+		Err:    fmt.Errorf("%w: "+format, append([]any{kind}, args...)...),
+	}
+}
+
+// For get the column an error of an annotation was found at, the column of the annotation when it has none
+func AnnotationErrorColumn(err error, column int) int {
+	var annotationErr *AnnotationError
+	if errors.As(err, &annotationErr) {
+		return annotationErr.Column
+	}
+
+	return column
+}
+
+type annotationTokenKind int
+
+const (
+	tokenEnd annotationTokenKind = iota
+	tokenWord
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenEquals
+)
+
+type annotationToken struct {
+	kind   annotationTokenKind
+	text   string
+	value  string
+	column int
+}
+
+// For describe a token in an error
+func (t *annotationToken) String() string {
+	if t.kind == tokenEnd {
+		return "end of line"
+	}
+
+	return t.text
+}
+
+// An argument with its key, empty for a positional one, and the columns of the key and the value
+type annotationArgument struct {
+	key       string
+	keyColumn int
+	value     string
+	column    int
+}
+
+// Reads the arguments of an annotation or a directive from a source line, the columns are the ones of the line
+type annotationParser struct {
+	line      string
+	offset    int
+	directive bool
+
+	// The sentinel error of the syntax errors, e.g. ErrMalformedAnnotation
+	malformed error
+}
+
+// For read the next token, the end of the line is a tokenEnd token
+func (p *annotationParser) next() (*annotationToken, error) {
+	for p.offset < len(p.line) && (p.line[p.offset] == ' ' || p.line[p.offset] == '\t') {
+		p.offset++
+	}
+
+	start := p.offset
+	column := start + 1
+	if start == len(p.line) {
+		return &annotationToken{kind: tokenEnd, column: column}, nil
+	}
+
+	punctuation := map[byte]annotationTokenKind{'(': tokenLeftParen, ')': tokenRightParen, ',': tokenComma, '=': tokenEquals}
+	if kind, ok := punctuation[p.line[start]]; ok {
+		p.offset++
+		return &annotationToken{kind: kind, text: p.line[start:p.offset], column: column}, nil
+	}
+
+	if quote := p.line[start]; quote == '"' || quote == '`' {
+		quoted, err := strconv.QuotedPrefix(p.line[start:])
+		if err != nil {
+			if strings.IndexByte(p.line[start+1:], quote) < 0 {
+				return nil, annotationErrorf(column, p.malformed, "string is not terminated")
+			}
+
+			return nil, annotationErrorf(column, p.malformed, "invalid string %s", p.line[start:])
+		}
+
+		// A prefix that QuotedPrefix accepts always unquotes
+		value, _ := strconv.Unquote(quoted)
+		p.offset += len(quoted)
+
+		return &annotationToken{kind: tokenString, text: quoted, value: value, column: column}, nil
+	}
+
+	for p.offset < len(p.line) {
+		r, size := utf8.DecodeRuneInString(p.line[p.offset:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-' {
+			break
+		}
+		p.offset += size
+	}
+
+	if p.offset == start {
+		r, _ := utf8.DecodeRuneInString(p.line[start:])
+		return nil, annotationErrorf(column, p.malformed, "unexpected %q", r)
+	}
+
+	word := p.line[start:p.offset]
+
+	return &annotationToken{kind: tokenWord, text: word, value: word, column: column}, nil
+}
+
+// For read the next token without moving past it
+func (p *annotationParser) peek() (*annotationToken, error) {
+	offset := p.offset
+	defer func() {
+		p.offset = offset
+	}()
+
+	return p.next()
+}
+
+// For read the arguments of an annotation, the parser starts after the ( of the marker
+func (p *annotationParser) parseCall() ([]*annotationArgument, error) {
+	arguments := make([]*annotationArgument, 0)
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.kind == tokenRightParen {
+			return arguments, nil
+		}
+
+		argument, err := p.parseArgument(tok)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+
+		tok, err = p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.kind {
+		case tokenRightParen:
+			return arguments, nil
+		case tokenComma:
+			continue
+		}
+
+		return nil, annotationErrorf(tok.column, p.malformed, "expected , or ) after the argument, found %s", tok)
+	}
+}
+
+// For read the arguments of a directive up to the end of the line
+func (p *annotationParser) parseDirective() ([]*annotationArgument, error) {
+	arguments := make([]*annotationArgument, 0)
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.kind == tokenEnd {
+			return arguments, nil
+		}
+
+		argument, err := p.parseArgument(tok)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+}
+
+// For read an argument from its first token, a word followed by = is a key
+func (p *annotationParser) parseArgument(tok *annotationToken) (*annotationArgument, error) {
+	following, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.kind == tokenWord && following.kind == tokenEquals {
+		if !token.IsIdentifier(tok.text) {
+			return nil, annotationErrorf(tok.column, p.malformed, "invalid key %s", tok)
+		}
+
+		// Move past the =
+		if _, err := p.next(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkValue(value, tok.text+"="); err != nil {
+			return nil, err
+		}
+
+		return &annotationArgument{key: tok.text, keyColumn: tok.column, value: value.value, column: value.column}, nil
+	}
+
+	if err := p.checkValue(tok, ""); err != nil {
+		return nil, err
+	}
+
+	return &annotationArgument{value: tok.value, column: tok.column}, nil
+}
+
+// For check that a token can be a value, an annotation only takes strings
+func (p *annotationParser) checkValue(tok *annotationToken, prefix string) error {
+	switch {
+	case tok.kind == tokenString, tok.kind == tokenWord && p.directive:
+		return nil
+	case tok.kind == tokenWord:
+		return annotationErrorf(tok.column, p.malformed, "%s%s must be a quoted string", prefix, tok)
+	case prefix != "":
+		return annotationErrorf(tok.column, p.malformed, "expected a value after %s, found %s", prefix, tok)
+	}
+
+	return annotationErrorf(tok.column, p.malformed, "expected an argument, found %s", tok)
+}
+
+const (
+	AnnotationMarker = "@WireSet("
+
+	// The Go directive form of an annotation, gofmt keeps it next to the function and go doc hides it
+	SetDirective = "//wiresetgen:set"
+)
+
+// The styles of the comment that puts a function in a set, the config picks the one new comments are written in
+const (
+	StyleAnnotation = "annotation"
+	StyleDirective  = "directive"
+)
+
+// The keyword arguments an annotation may have, the set name is the positional argument or the set key
+var annotationKeys = map[string]func(annotation *models.Annotation, value string) error{
+	"name": func(annotation *models.Annotation, value string) error {
+		annotation.Name = value
+		return nil
+	},
+	"bind": func(annotation *models.Annotation, value string) error {
+		annotation.Bind = value
+		return nil
+	},
+	"profile": func(annotation *models.Annotation, value string) (err error) {
+		annotation.Profiles, err = parseProfiles(value)
+		return err
+	},
+}
+
+// The set key names the set like the positional argument
+const setNameKey = "set"
+
+// An annotation of a line with its arguments, the comment is the offset of its // and end the offset after it
+type parsedAnnotation struct {
+	annotation *models.Annotation
+	arguments  []*annotationArgument
+	comment    int
+	end        int
+}
+
+// For find every @WireSet annotation and //wiresetgen:set directive in a comment with its position
+// The annotation is returned with Err set when it is malformed or not followed by a function,
+// an unknown key does not stop it from being used and is in Warnings
+func ExtractAnnotations(fileContent string) []*models.Annotation {
+	annotations := make([]*models.Annotation, 0)
+	for _, parsed := range parseAnnotations(strings.Split(fileContent, "\n")) {
+		annotations = append(annotations, parsed.annotation)
+	}
+
+	return annotations
+}
+
+// For parse the annotations of the lines, the arguments are kept for ConvertAnnotations
+func parseAnnotations(lines []string) []*parsedAnnotation {
+	annotations := make([]*parsedAnnotation, 0)
+
+	for i := range lines {
+		parsed, parser := findAnnotation(lines[i])
+		if parsed == nil {
+			continue
+		}
+		annotation := parsed.annotation
+		annotation.Line = i + 1
+		annotations = append(annotations, parsed)
+
+		var err error
+		if annotation.Directive {
+			parsed.arguments, err = parser.parseDirective()
+		} else {
+			parsed.arguments, err = parser.parseCall()
+		}
+		parsed.end = parser.offset
+		if err != nil {
+			annotation.Err = err
+			continue
+		}
+
+		if err := parser.bind(annotation, parsed.arguments); err != nil {
+			annotation.Err = err
+			continue
+		}
+
+		// Extract the function name from the next line
+		functionName := ""
+		if i+1 < len(lines) {
+			functionName = extractFunctionName(lines[i+1])
+		}
+		if functionName == "" {
+			annotation.Err = ErrAnnotationNotOnFunction
+			continue
+		}
+
+		annotation.FunctionName = functionName
+	}
+
+	return annotations
+}
+
+// For find the annotation of a line and the parser of its arguments, nil when the line has none
+// A directive starts the comment like any Go directive, an annotation may follow text in the comment
+func findAnnotation(line string) (*parsedAnnotation, *annotationParser) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if rest, ok := strings.CutPrefix(trimmed, SetDirective); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+		comment := len(line) - len(trimmed)
+		parsed := &parsedAnnotation{
+			annotation: &models.Annotation{Column: comment + 1, Directive: true},
+			comment:    comment,
+		}
+
+		return parsed, &annotationParser{line: line, offset: comment + len(SetDirective), directive: true, malformed: ErrMalformedDirective}
+	}
+
+	markerIndex := strings.Index(line, AnnotationMarker)
+	commentIndex := strings.Index(line, "//")
+	if markerIndex < 0 || commentIndex < 0 || commentIndex > markerIndex {
+		return nil, nil
+	}
+
+	parsed := &parsedAnnotation{
+		annotation: &models.Annotation{Column: markerIndex + 1},
+		comment:    commentIndex,
+	}
+
+	return parsed, &annotationParser{line: line, offset: markerIndex + len(AnnotationMarker), malformed: ErrMalformedAnnotation}
+}
+
+// For fill an annotation from its arguments
+func (p *annotationParser) bind(annotation *models.Annotation, arguments []*annotationArgument) error {
+	nameColumn := 0
+	seen := make(map[string]bool)
+	for _, argument := range arguments {
+		key := argument.key
+		if key == "" {
+			key = setNameKey
+		}
+
+		if seen[key] {
+			if argument.key == "" {
+				return annotationErrorf(argument.column, p.malformed, "unexpected argument %q, only the set name has no key", argument.value)
+			}
+
+			return annotationErrorf(argument.keyColumn, p.malformed, "%s is given twice", key)
+		}
+		seen[key] = true
+
+		if key == setNameKey {
+			annotation.SetName = strings.TrimSpace(argument.value)
+			if annotation.SetName == "" {
+				return annotationErrorf(argument.column, p.malformed, "the set name is empty")
+			}
+
+			// The set name of a string starts after the quote and the spaces before it
+			nameColumn = argument.column + strings.Index(argument.value, annotation.SetName)
+			if quote := p.line[argument.column-1]; quote == '"' || quote == '`' {
+				nameColumn++
+			}
+			continue
+		}
+
+		setValue, known := annotationKeys[key]
+		if !known {
+			annotation.Warnings = append(annotation.Warnings, annotationErrorf(argument.keyColumn, ErrUnknownAnnotationKey,
+				"%s is ignored, the keys are %s", key, annotationKeyList()))
+			continue
+		}
+
+		if argument.value == "" {
+			return annotationErrorf(argument.column, p.malformed, "%s is empty", key)
+		}
+		if err := setValue(annotation, argument.value); err != nil {
+			return err
+		}
+	}
+
+	if nameColumn == 0 {
+		return annotationErrorf(annotation.Column, p.malformed, "the set name is missing")
+	}
+
+	return ValidateSetName(annotation.SetName, nameColumn)
+}
+
+// For check an annotation style of the config or the command line, empty is the default style
+func ValidateStyle(style string) error {
+	switch style {
+	case "", StyleAnnotation, StyleDirective:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q, expected %s or %s", ErrUnknownStyle, style, StyleAnnotation, StyleDirective)
+}
+
+// For write the comment of the arguments in a style, e.g. // @WireSet("Service", bind="io.Reader")
+// or //wiresetgen:set Service bind=io.Reader, a directive value is quoted only when it is not a word
+func formatAnnotation(arguments []*annotationArgument, style string) string {
+	values := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		value := strconv.Quote(argument.value)
+		if style == StyleDirective && isWord(argument.value) {
+			value = argument.value
+		}
+
+		if argument.key != "" {
+			value = argument.key + "=" + value
+		}
+		values = append(values, value)
+	}
+
+	if style == StyleDirective {
+		return SetDirective + " " + strings.Join(values, " ")
+	}
+
+	return "// " + AnnotationMarker + strings.Join(values, ", ") + ")"
+}
+
+// For check that a value can be written as a word of a directive
+func isWord(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// For get the comment line that annotates a function with the set
+func AnnotationComment(setName string, style string) string {
+	return formatAnnotation([]*annotationArgument{{value: setName}}, style)
+}
+
+// For rewrite the usable annotations of a file in a style, the arguments and their order are kept
+// Text around an annotation in its comment stays in a comment line above it
+// The converted annotations are returned with the line they had
+func ConvertAnnotations(fileContent string, style string) (string, []*models.Annotation) {
+	lines := strings.Split(fileContent, "\n")
+	converted := make([]*models.Annotation, 0)
+	rewritten := make(map[int][]string)
+
+	for _, parsed := range parseAnnotations(lines) {
+		annotation := parsed.annotation
+		if annotation.Err != nil || annotation.Directive == (style == StyleDirective) {
+			continue
+		}
+
+		line := lines[annotation.Line-1]
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		replacement := make([]string, 0, 2)
+
+		// An annotation in the comment after code stays, the text of the comment
+		// before the marker and after the ) is kept
+		if !annotation.Directive {
+			if strings.TrimSpace(line[:parsed.comment]) != "" {
+				continue
+			}
+
+			before := strings.TrimSpace(strings.TrimPrefix(line[parsed.comment:annotation.Column-1], "//"))
+			after := strings.TrimSpace(line[parsed.end:])
+			if text := strings.TrimSpace(before + " " + after); text != "" {
+				replacement = append(replacement, indent+"// "+text)
+			}
+		}
+
+		rewritten[annotation.Line] = append(replacement, indent+formatAnnotation(parsed.arguments, style))
+		converted = append(converted, annotation)
+	}
+
+	if len(converted) == 0 {
+		return fileContent, converted
+	}
+
+	result := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if replacement, ok := rewritten[i+1]; ok {
+			result = append(result, replacement...)
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n"), converted
+}
+
+// For list the keys of an annotation for a warning, e.g. bind, name, profile and set
+func annotationKeyList() string {
+	keys := []string{setNameKey}
+	for key := range annotationKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys[:len(keys)-1], ", ") + " and " + keys[len(keys)-1]
+}
+
+// A directive of a file with the 1-based line and column of its marker
+type directive struct {
+	marker    string
+	line      int
+	column    int
+	arguments []*annotationArgument
+}
+
+// For find the first directive with the marker, nil when the lines have none
+// A syntax error has the line and the column it was found at
+func extractDirective(lines []string, marker string) (*directive, error) {
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(trimmed, marker+" ") {
+			continue
+		}
+
+		parser := &annotationParser{
+			line:      line,
+			offset:    len(line) - len(trimmed) + len(marker),
+			directive: true,
+			malformed: ErrMalformedDirective,
+		}
+		arguments, err := parser.parseDirective()
+		if err != nil {
+			return nil, fmt.Errorf("%d:%d: %w", i+1, AnnotationErrorColumn(err, 1), err)
+		}
+
+		return &directive{marker: marker, line: i + 1, column: len(line) - len(trimmed) + 1, arguments: arguments}, nil
+	}
+
+	return nil, nil
+}
+
+// For prefix an error of an argument of the directive with its position
+func (d *directive) errorAt(column int, err error) error {
+	return fmt.Errorf("%d:%d: %w", d.line, column, err)
+}
+
+// For get the values of a directive that takes no keys
+func (d *directive) values() ([]string, error) {
+	values := make([]string, 0, len(d.arguments))
+	for _, argument := range d.arguments {
+		if argument.key != "" {
+			return nil, d.errorAt(argument.keyColumn, annotationErrorf(argument.keyColumn, ErrMalformedDirective, "%s takes no %s= argument", d.marker, argument.key))
+		}
+
+		values = append(values, argument.value)
+	}
+
+	return values, nil
+}
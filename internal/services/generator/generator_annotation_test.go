@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_annotationParser_parseCall(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		line              string
+		expectedArguments []*annotationArgument
+		expectedError     error
+	}{
+		{
+			name: "Positional and keys",
+			line: `// @WireSet("Service", bind="io.Reader") trailing text`,
+			expectedArguments: []*annotationArgument{
+				{value: "Service", column: 13},
+				{key: "bind", keyColumn: 24, value: "io.Reader", column: 29},
+			},
+		},
+		{
+			name:              "Escapes and raw strings",
+			line:              "// @WireSet(\"Ser\\u0076ice\", name=`a\"b`)",
+			expectedArguments: []*annotationArgument{{value: "Service", column: 13}, {key: "name", keyColumn: 29, value: `a"b`, column: 34}},
+		},
+		{
+			name:              "No arguments",
+			line:              `// @WireSet( )`,
+			expectedArguments: []*annotationArgument{},
+		},
+		{
+			name:          "Missing comma",
+			line:          `// @WireSet("Service" bind="io.Reader")`,
+			expectedError: annotationErrorf(23, ErrMalformedAnnotation, "expected , or ) after the argument, found bind"),
+		},
+		{
+			name:          "Missing closing parenthesis",
+			line:          `// @WireSet("Service"`,
+			expectedError: annotationErrorf(22, ErrMalformedAnnotation, "expected , or ) after the argument, found end of line"),
+		},
+		{
+			name:          "Unterminated string",
+			line:          `// @WireSet("Service)`,
+			expectedError: annotationErrorf(13, ErrMalformedAnnotation, "string is not terminated"),
+		},
+		{
+			name:          "Invalid escape",
+			line:          `// @WireSet("Ser\qvice")`,
+			expectedError: annotationErrorf(13, ErrMalformedAnnotation, `invalid string "Ser\qvice")`),
+		},
+		{
+			name:          "Missing value",
+			line:          `// @WireSet("Service", bind=)`,
+			expectedError: annotationErrorf(29, ErrMalformedAnnotation, "expected a value after bind=, found )"),
+		},
+		{
+			name:          "Key is not an identifier",
+			line:          `// @WireSet("Service", io.bind="x")`,
+			expectedError: annotationErrorf(24, ErrMalformedAnnotation, "invalid key io.bind"),
+		},
+		{
+			name:          "Unexpected character",
+			line:          `// @WireSet("Service"; bind="x")`,
+			expectedError: annotationErrorf(22, ErrMalformedAnnotation, "unexpected ';'"),
+		},
+		{
+			name:          "Leading comma",
+			line:          `// @WireSet(, "Service")`,
+			expectedError: annotationErrorf(13, ErrMalformedAnnotation, "expected an argument, found ,"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser := &annotationParser{line: tt.line, offset: len("// @WireSet("), malformed: ErrMalformedAnnotation}
+			arguments, err := parser.parseCall()
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedArguments, arguments)
+			}
+		})
+	}
+}
+
+func Test_extractDirective(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{"//go:build wireinject", "", "\t//wiresetgen:profile dev `prod` ", "package main"}
+
+	found, err := extractDirective(lines, ProfileDirective)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &directive{
+		marker: ProfileDirective,
+		line:   3,
+		column: 2,
+		arguments: []*annotationArgument{
+			{value: "dev", column: 23},
+			{value: "prod", column: 27},
+		},
+	}, found)
+
+	found, err = extractDirective(lines, BackendDirective)
+
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	_, err = extractDirective([]string{"//wiresetgen:profile dev,prod"}, ProfileDirective)
+
+	assert.ErrorIs(t, err, ErrMalformedDirective)
+	assert.EqualError(t, err, "1:25: malformed //wiresetgen directive: expected an argument, found ,")
+}
+
+func Test_extractBackendDirective(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		line          string
+		expected      string
+		expectedError string
+	}{
+		{name: "Backend", line: "//wiresetgen:backend dig", expected: "dig"},
+		{name: "Quoted backend", line: `//wiresetgen:backend "fx"`, expected: "fx"},
+		{name: "Several backends", line: "//wiresetgen:backend dig fx", expectedError: "1:1: malformed //wiresetgen directive: //wiresetgen:backend takes one backend"},
+		{name: "Key", line: "//wiresetgen:backend name=dig", expectedError: "1:22: malformed //wiresetgen directive: //wiresetgen:backend takes no name= argument"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend, err := extractBackendDirective([]string{tt.line})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, backend)
+		})
+	}
+}
+
+func TestConvertAnnotations(t *testing.T) {
+	t.Parallel()
+
+	content := "package user\n\n" +
+		"// NewRepo creates the repository\n" +
+		"// @WireSet(\"User\", bind=\"io.Reader\", profile=\"dev,test\")\n" +
+		"func NewRepo() *Repo {\n}\n\n" +
+		"// Cached @WireSet(`User`, name=\"cache\") by key\n" +
+		"func NewCache() *Cache {\n}\n\n" +
+		"//wiresetgen:set User\n" +
+		"func NewClock() *Clock {\n}\n\n" +
+		"// @WireSet(\"User\")\n" +
+		"type Broken struct{}\n"
+
+	converted, annotations := ConvertAnnotations(content, StyleDirective)
+
+	assert.Equal(t, "package user\n\n"+
+		"// NewRepo creates the repository\n"+
+		"//wiresetgen:set User bind=io.Reader profile=\"dev,test\"\n"+
+		"func NewRepo() *Repo {\n}\n\n"+
+		"// Cached by key\n"+
+		"//wiresetgen:set User name=cache\n"+
+		"func NewCache() *Cache {\n}\n\n"+
+		"//wiresetgen:set User\n"+
+		"func NewClock() *Clock {\n}\n\n"+
+		"// @WireSet(\"User\")\n"+
+		"type Broken struct{}\n", converted)
+	if assert.Len(t, annotations, 2) {
+		assert.Equal(t, 4, annotations[0].Line)
+		assert.Equal(t, "NewCache", annotations[1].FunctionName)
+	}
+
+	converted, annotations = ConvertAnnotations(converted, StyleAnnotation)
+
+	assert.Len(t, annotations, 3)
+	assert.Contains(t, converted, "// @WireSet(\"User\", bind=\"io.Reader\", profile=\"dev,test\")\nfunc NewRepo")
+	assert.Contains(t, converted, "// @WireSet(\"User\")\nfunc NewClock")
+
+	unchanged, annotations := ConvertAnnotations(converted, StyleAnnotation)
+
+	assert.Empty(t, annotations)
+	assert.Equal(t, converted, unchanged)
+}
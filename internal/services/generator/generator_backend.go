@@ -0,0 +1,389 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/templates"
+)
+
+const (
+	BackendWire  = "wire"
+	BackendFx    = "fx"
+	BackendPlain = "plain"
+	BackendDo    = "do"
+	BackendDig   = "dig"
+
+	FxModuleGenFileName   = "fx_module_gen.go"
+	BuildGenFileName      = "build_gen.go"
+	DoRegisterGenFileName = "do_register_gen.go"
+	DigProvideGenFileName = "dig_provide_gen.go"
+
+	// The directive of an injector file that selects the backend of its location
+	BackendDirective = "//wiresetgen:backend"
+)
+
+// An output backend renders the annotated sets of a location into one file
+type backend struct {
+	name     string
+	fileName string
+	template string
+
+	// The template renders a Build function, its body is generated so the output is formatted
+	build  bool
+	format bool
+
+	// Providers returning a func() cleanup are refused when the library has no cleanup functions,
+	// fx would provide the func() as a value and never call it
+	noCleanup bool
+
+	// The types the template writes for a provider, besides the provider function
+	providerTypes func(setInfo *models.WireSetInfo) []string
+
+	// The default affix of the set variables, e.g. Set for ServiceSet
+	affix string
+}
+
+var backends = map[string]*backend{
+	BackendWire: {
+		name:     BackendWire,
+		fileName: WireSetGenFileName,
+		template: templates.WireSetGenTemplate,
+		affix:    DefaultSetAffix,
+		providerTypes: func(setInfo *models.WireSetInfo) []string {
+			if setInfo.Bind == "" {
+				return nil
+			}
+
+			return []string{setInfo.Bind, ProvidedType(setInfo.ResultTypes)}
+		},
+	},
+	BackendFx: {
+		name:          BackendFx,
+		fileName:      FxModuleGenFileName,
+		template:      templates.FxModuleGenTemplate,
+		noCleanup:     true,
+		providerTypes: bindType,
+		affix:         "Module",
+	},
+	BackendPlain: {
+		name:     BackendPlain,
+		fileName: BuildGenFileName,
+		template: templates.BuildGenTemplate,
+		build:    true,
+		format:   true,
+		affix:    DefaultSetAffix,
+		providerTypes: func(setInfo *models.WireSetInfo) []string {
+			return append(append([]string{}, setInfo.ParamTypes...), setInfo.ResultTypes...)
+		},
+	},
+	BackendDo: {
+		name:      BackendDo,
+		fileName:  DoRegisterGenFileName,
+		template:  templates.DoRegisterGenTemplate,
+		format:    true,
+		noCleanup: true,
+		affix:     DefaultSetAffix,
+		providerTypes: func(setInfo *models.WireSetInfo) []string {
+			types := append(providerParamTypes(setInfo), ProvidedType(setInfo.ResultTypes))
+			if setInfo.Bind != "" {
+				types = append(types, setInfo.Bind)
+			}
+
+			return types
+		},
+	},
+	BackendDig: {
+		name:          BackendDig,
+		fileName:      DigProvideGenFileName,
+		template:      templates.DigProvideGenTemplate,
+		format:        true,
+		noCleanup:     true,
+		providerTypes: bindType,
+		affix:         DefaultSetAffix,
+	},
+}
+
+func bindType(setInfo *models.WireSetInfo) []string {
+	if setInfo.Bind == "" {
+		return nil
+	}
+
+	return []string{setInfo.Bind}
+}
+
+// For get the backend by name, an empty name is the wire backend
+func getBackend(name string) (*backend, error) {
+	if name == "" {
+		name = BackendWire
+	}
+
+	selected, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, name)
+	}
+
+	return selected, nil
+}
+
+// For group the providers by set, sorted by set name, with the imports the backend writes
+// The variables are named by the naming of the config with the affix of the backend,
+// the providers of the location package are written without the import
+func newWireSets(
+	setInfos []*models.WireSetInfo,
+	selected *backend,
+	naming *models.NamingConfig,
+	localImportPath string,
+	importMap map[string]string,
+) ([]*models.WireSet, []*models.ImportTemplate, error) {
+	setInfoMap := make(map[string][]*models.WireSetInfo)
+	for _, setInfo := range setInfos {
+		setInfoMap[setInfo.SetName] = append(setInfoMap[setInfo.SetName], setInfo)
+	}
+
+	naming = backendNaming(naming, selected)
+
+	wireSets := make([]*models.WireSet, 0, len(setInfoMap))
+	for setName, setInfos := range setInfoMap {
+		wireSet := &models.WireSet{
+			SetName: setName,
+			VarName: SetVarName(setName, naming),
+		}
+
+		for _, info := range setInfos {
+			if err := checkImportable(info, localImportPath); err != nil {
+				return nil, nil, err
+			}
+
+			provider := newProviderTemplate(info, localImportPath, importMap)
+			if selected.noCleanup && provider.Cleanup {
+				return nil, nil, fmt.Errorf("%w: %s.%s returns a cleanup, %s has no cleanup functions",
+					ErrUnsupportedProvider, info.PackageName, info.FunctionName, selected.name)
+			}
+
+			wireSet.FuncPath = append(wireSet.FuncPath, provider.FuncPath)
+			wireSet.Providers = append(wireSet.Providers, provider)
+		}
+
+		wireSets = append(wireSets, wireSet)
+	}
+
+	sort.Slice(wireSets, func(i, j int) bool {
+		return wireSets[i].SetName < wireSets[j].SetName
+	})
+
+	// The exact strategy keeps a keyword and two set names may get the same variable, e.g. Service and ServiceSet
+	varNames := make(map[string]string)
+	for _, wireSet := range wireSets {
+		if !token.IsIdentifier(wireSet.VarName) {
+			return nil, nil, fmt.Errorf("%w: %s is generated as %q, not a Go identifier", ErrInvalidSetName, wireSet.SetName, wireSet.VarName)
+		}
+
+		if setName, exists := varNames[wireSet.VarName]; exists {
+			return nil, nil, fmt.Errorf("%w: %s and %s are both generated as %s", ErrDuplicateSetName, setName, wireSet.SetName, wireSet.VarName)
+		}
+		varNames[wireSet.VarName] = wireSet.SetName
+	}
+
+	importPaths := backendImportPaths(setInfos, selected, localImportPath)
+	imports := make([]*models.ImportTemplate, 0, len(importPaths))
+	for _, importPath := range importPaths {
+		imports = append(imports, &models.ImportTemplate{
+			Alias: importMap[importPath],
+			Path:  importPath,
+		})
+	}
+
+	return wireSets, imports, nil
+}
+
+// Parameters are named for the backends that resolve them one by one, they never shadow an import
+func newProviderTemplate(setInfo *models.WireSetInfo, localImportPath string, importMap map[string]string) *models.ProviderTemplate {
+	providedType := ProvidedType(setInfo.ResultTypes)
+	provider := &models.ProviderTemplate{
+		FuncPath: setInfo.FunctionName,
+		Type:     SourceType(providedType, localImportPath, importMap),
+		Name:     setInfo.Name,
+		Params:   make([]*models.InjectorParam, 0, len(setInfo.ParamTypes)),
+
+		Function:    setInfo.FunctionName,
+		PackageName: setInfo.PackageName,
+		ImportPath:  setInfo.ImportPath,
+		FilePath:    setInfo.FilePath,
+		Line:        setInfo.Line,
+		Doc:         setInfo.Doc,
+	}
+	if setInfo.ImportPath != localImportPath {
+		provider.FuncPath = importMap[setInfo.ImportPath] + "." + setInfo.FunctionName
+	}
+	provider.Zero = zeroValue(providedType, provider.Type)
+
+	if setInfo.Bind != "" {
+		provider.Bind = SourceType(setInfo.Bind, localImportPath, importMap)
+	}
+
+	for _, resultType := range setInfo.ResultTypes {
+		provider.Cleanup = provider.Cleanup || resultType == "func()"
+		provider.Error = provider.Error || resultType == "error"
+	}
+
+	usedNames := map[string]bool{"i": true, "err": true}
+	for importPath, alias := range importMap {
+		if importPath != localImportPath {
+			usedNames[alias] = true
+		}
+	}
+	for _, paramType := range providerParamTypes(setInfo) {
+		provider.Params = append(provider.Params, &models.InjectorParam{
+			Name: TypeVarName(paramType, usedNames),
+			Type: SourceType(paramType, localImportPath, importMap),
+		})
+	}
+
+	return provider
+}
+
+// For pick the backend of a location
+// The directive of the injector file wins, then the config of its directory, the command line and the config default
+// The file of a previous backend is removed when the location is written, see removeStaleFiles
+func locationBackend(wireGenLocation *models.WireGenLocation, config *models.Config, defaultBackend string) (*backend, error) {
+	name := wireGenLocation.Backend
+	if name == "" {
+		name = config.Backends[filepath.ToSlash(wireGenLocation.DirectoryPath)]
+	}
+	if name == "" {
+		name = defaultBackend
+	}
+	if name == "" {
+		name = config.Backend
+	}
+
+	selected, err := getBackend(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", wireGenLocation.DirectoryPath, err)
+	}
+
+	return selected, nil
+}
+
+// For check if a file name is the output of any backend, with or without a profile and a constrained set
+func IsGeneratedFileName(fileName string) bool {
+	fileName, _ = SplitProfileFileName(fileName)
+	for _, selected := range backends {
+		if base, _ := SplitSetFileName(fileName, selected.fileName); base == selected.fileName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// For check that the package of a provider can be imported by the location, a provider of the location package
+// is always used in place. A main package, a testdata directory and a directory starting with _ or . are
+// never importable, the go command ignores the last ones
+func checkImportable(setInfo *models.WireSetInfo, localImportPath string) error {
+	if setInfo.ImportPath == localImportPath {
+		return nil
+	}
+
+	if setInfo.PackageName == "main" || strings.HasSuffix(setInfo.PackageName, "_test") {
+		return fmt.Errorf("%w: %s.%s is in package %s of %s, move it to another package",
+			ErrUnimportablePackage, setInfo.PackageName, setInfo.FunctionName, setInfo.PackageName, setInfo.ImportPath)
+	}
+
+	for _, element := range strings.Split(filepath.ToSlash(filepath.Dir(setInfo.FilePath)), "/") {
+		if element == "testdata" || (element != "." && (strings.HasPrefix(element, "_") || strings.HasPrefix(element, "."))) {
+			return fmt.Errorf("%w: %s.%s is in directory %s of %s, that the go command ignores",
+				ErrUnimportablePackage, setInfo.PackageName, setInfo.FunctionName, element, setInfo.ImportPath)
+		}
+	}
+
+	return nil
+}
+
+// Names an import never takes, the generated files use them for the DI libraries
+var reservedImportNames = map[string]bool{"wire": true, "fx": true, "dig": true, "do": true}
+
+// For list the packages the backend writes into the file, sorted by import path
+// The location package is never imported, the file is part of it
+func backendImportPaths(setInfos []*models.WireSetInfo, selected *backend, localImportPath string) []string {
+	usedImports := make(map[string]bool)
+	for _, setInfo := range setInfos {
+		usedImports[setInfo.ImportPath] = true
+		if selected.providerTypes != nil {
+			for _, importPath := range TypeImportPaths(selected.providerTypes(setInfo)) {
+				usedImports[importPath] = true
+			}
+		}
+	}
+	delete(usedImports, localImportPath)
+
+	importPaths := make([]string, 0, len(usedImports))
+	for importPath := range usedImports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	return importPaths
+}
+
+// For give every import path an alias, the package name when it is free, else the name with a number
+// The paths are sorted first so the aliases never depend on the scan order, a reserved name,
+// a predeclared identifier and a DI library name are never taken
+func importAliases(importPaths []string, packageNames map[string]string, reserved map[string]bool) map[string]string {
+	sorted := sortStrings(importPaths)
+
+	importMap := make(map[string]string)
+	usedAliases := make(map[string]bool)
+	for _, importPath := range sorted {
+		if _, exists := importMap[importPath]; exists {
+			continue
+		}
+
+		name, known := packageNames[importPath]
+		if !known {
+			name = DefaultImportName(importPath)
+		}
+		if token.IsKeyword(name) {
+			name += "pkg"
+		}
+		if !token.IsIdentifier(name) {
+			name = "pkg"
+		}
+
+		alias := name
+		for i := 2; usedAliases[alias] || reserved[alias] || reservedImportNames[alias] || types.Universe.Lookup(alias) != nil; i++ {
+			alias = name + strconv.Itoa(i)
+		}
+		usedAliases[alias] = true
+
+		importMap[importPath] = alias
+	}
+
+	return importMap
+}
+
+// For drop the aliases that are the name of their package, the import reads the same without them
+func omitAliases(imports []*models.ImportTemplate, packageNames map[string]string) {
+	for _, importTemplate := range imports {
+		if name, known := packageNames[importTemplate.Path]; known && name == importTemplate.Alias {
+			importTemplate.Alias = ""
+		}
+	}
+}
+
+// For check if an import path is a standard library package, its first element has no dot
+// The packages of a module without a dot in its name are not
+func isStandardImportPath(importPath string, moduleName string) bool {
+	if importPath == moduleName || strings.HasPrefix(importPath, moduleName+"/") {
+		return false
+	}
+
+	return !strings.Contains(strings.Split(importPath, "/")[0], ".")
+}
@@ -0,0 +1,331 @@
+package generator
+
+import (
+	"bytes"
+	"path"
+	"testing"
+	"text/template"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newWireSets(t *testing.T) {
+	t.Parallel()
+
+	setInfos := []*models.WireSetInfo{
+		{
+			PackageName:  "service",
+			SetName:      "Service",
+			FunctionName: "NewService",
+			ImportPath:   "github.com/foo/bar/service",
+			ParamTypes:   []string{"*github.com/foo/bar/repo.Repository", "...string"},
+			ResultTypes:  []string{"*github.com/foo/bar/service.Service", "error"},
+			Name:         "primary",
+			Bind:         "io.Reader",
+			FilePath:     "service/service.go",
+			Line:         12,
+			Doc:          "NewService builds the service.",
+		},
+		{
+			PackageName:  "repo",
+			SetName:      "Repository",
+			FunctionName: "NewRepository",
+			ImportPath:   "github.com/foo/bar/repo",
+			ParamTypes:   []string{"*database/sql.DB"},
+			ResultTypes:  []string{"*github.com/foo/bar/repo.Repository", "func()"},
+		},
+	}
+	importMap := importAliases([]string{"github.com/foo/bar/service", "github.com/foo/bar/repo", "database/sql", "io"}, nil, nil)
+
+	testCases := []struct {
+		name            string
+		backend         string
+		setInfos        []*models.WireSetInfo
+		expectedImports []string
+		expectedError   error
+	}{
+		{
+			name:            "Wire writes the bound type",
+			backend:         BackendWire,
+			setInfos:        setInfos,
+			expectedImports: []string{"github.com/foo/bar/repo", "github.com/foo/bar/service", "io"},
+		},
+		{
+			name:            "Dig writes only the interface",
+			backend:         BackendDig,
+			setInfos:        setInfos[:1],
+			expectedImports: []string{"github.com/foo/bar/service", "io"},
+		},
+		{
+			name:            "Do writes every type",
+			backend:         BackendDo,
+			setInfos:        setInfos[:1],
+			expectedImports: []string{"github.com/foo/bar/repo", "github.com/foo/bar/service", "io"},
+		},
+		{
+			name:          "Fx has no cleanup",
+			backend:       BackendFx,
+			setInfos:      setInfos,
+			expectedError: ErrUnsupportedProvider,
+		},
+		{
+			name:          "Do has no cleanup",
+			backend:       BackendDo,
+			setInfos:      setInfos,
+			expectedError: ErrUnsupportedProvider,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, err := getBackend(tt.backend)
+			assert.NoError(t, err)
+
+			wireSets, imports, err := newWireSets(tt.setInfos, selected, nil, "", importMap)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			importPaths := make([]string, 0, len(imports))
+			for _, importTemplate := range imports {
+				importPaths = append(importPaths, importTemplate.Path)
+			}
+			assert.Equal(t, tt.expectedImports, importPaths)
+
+			service := wireSets[len(wireSets)-1]
+			assert.Equal(t, "ServiceSet", service.VarName)
+			assert.Equal(t, []string{"service.NewService"}, service.FuncPath)
+			assert.Equal(t, []*models.ProviderTemplate{
+				{
+					FuncPath: "service.NewService",
+					Type:     "*service.Service",
+					Zero:     "nil",
+					Name:     "primary",
+					Bind:     "io.Reader",
+					Params:   []*models.InjectorParam{{Name: "repository", Type: "*repo.Repository"}},
+					Error:    true,
+
+					Function:    "NewService",
+					PackageName: "service",
+					ImportPath:  "github.com/foo/bar/service",
+					FilePath:    "service/service.go",
+					Line:        12,
+					Doc:         "NewService builds the service.",
+				},
+			}, service.Providers)
+		})
+	}
+}
+
+func Test_newWireSets_localPackage(t *testing.T) {
+	t.Parallel()
+
+	setInfos := []*models.WireSetInfo{
+		{
+			PackageName:  "main",
+			SetName:      "App",
+			FunctionName: "NewApp",
+			ImportPath:   "github.com/foo/bar/cmd/app",
+			ParamTypes:   []string{"*github.com/foo/bar/service.Service", "github.com/foo/bar/cmd/app.Config"},
+			ResultTypes:  []string{"*github.com/foo/bar/cmd/app.App"},
+			Bind:         "github.com/foo/bar/cmd/app.Runner",
+			FilePath:     "cmd/app/app.go",
+		},
+	}
+	importMap := importAliases([]string{"github.com/foo/bar/service"}, nil, nil)
+
+	selected, err := getBackend(BackendDo)
+	assert.NoError(t, err)
+
+	wireSets, imports, err := newWireSets(setInfos, selected, nil, "github.com/foo/bar/cmd/app", importMap)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.ImportTemplate{{Alias: "service", Path: "github.com/foo/bar/service"}}, imports)
+	assert.Equal(t, []string{"NewApp"}, wireSets[0].FuncPath)
+
+	provider := wireSets[0].Providers[0]
+	assert.Equal(t, "*App", provider.Type)
+	assert.Equal(t, "Runner", provider.Bind)
+	assert.Equal(t, []*models.InjectorParam{
+		{Name: "service2", Type: "*service.Service"},
+		{Name: "config", Type: "Config"},
+	}, provider.Params)
+
+	// Any other location would import the main package
+	_, _, err = newWireSets(setInfos, selected, nil, "github.com/foo/bar/cmd/worker", importMap)
+	assert.ErrorIs(t, err, ErrUnimportablePackage)
+}
+
+func Test_checkImportable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		packageName string
+		filePath    string
+		importable  bool
+	}{
+		{name: "Library package", packageName: "service", filePath: "internal/service/service.go", importable: true},
+		{name: "Root package", packageName: "bar", filePath: "bar.go", importable: true},
+		{name: "Main package", packageName: "main", filePath: "cmd/api/main.go"},
+		{name: "External test package", packageName: "service_test", filePath: "service/service.go"},
+		{name: "Testdata directory", packageName: "fixture", filePath: "service/testdata/fixture/fixture.go"},
+		{name: "Underscore directory", packageName: "old", filePath: "_old/old.go"},
+		{name: "Dot directory", packageName: "tools", filePath: ".tools/tools.go"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			setInfo := &models.WireSetInfo{
+				PackageName:  tt.packageName,
+				FunctionName: "NewService",
+				ImportPath:   path.Join("github.com/foo/bar", path.Dir(tt.filePath)),
+				FilePath:     tt.filePath,
+			}
+
+			err := checkImportable(setInfo, "github.com/foo/bar/cmd/app")
+
+			if tt.importable {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrUnimportablePackage)
+			}
+
+			// The location package is always importable, it is not imported
+			assert.NoError(t, checkImportable(setInfo, setInfo.ImportPath))
+		})
+	}
+}
+
+func Test_locationBackend(t *testing.T) {
+	t.Parallel()
+
+	config := &models.Config{
+		Backend:  BackendFx,
+		Backends: map[string]string{"cmd/worker": BackendDig},
+	}
+
+	testCases := []struct {
+		name            string
+		location        *models.WireGenLocation
+		defaultBackend  string
+		expectedBackend string
+	}{
+		{
+			name:            "Directive",
+			location:        &models.WireGenLocation{DirectoryPath: "cmd/worker", Backend: BackendDo},
+			defaultBackend:  BackendPlain,
+			expectedBackend: BackendDo,
+		},
+		{
+			name:            "Location config",
+			location:        &models.WireGenLocation{DirectoryPath: "cmd/worker"},
+			defaultBackend:  BackendPlain,
+			expectedBackend: BackendDig,
+		},
+		{
+			name:            "Command line",
+			location:        &models.WireGenLocation{DirectoryPath: "cmd/api"},
+			defaultBackend:  BackendPlain,
+			expectedBackend: BackendPlain,
+		},
+		{
+			name:            "Config default",
+			location:        &models.WireGenLocation{DirectoryPath: "cmd/api"},
+			expectedBackend: BackendFx,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, err := locationBackend(tt.location, config, tt.defaultBackend)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBackend, selected.name)
+		})
+	}
+}
+
+func Test_importAliases(t *testing.T) {
+	t.Parallel()
+
+	importPaths := []string{
+		"github.com/foo/bar/wire",
+		"github.com/foo/bar/b/service",
+		"github.com/foo/bar/a/service",
+		"github.com/foo/bar/go-redis",
+		"github.com/foo/bar/type",
+		"github.com/foo/bar/error",
+		"github.com/foo/bar/repo",
+		"github.com/foo/bar/a/service",
+	}
+	packageNames := map[string]string{"github.com/foo/bar/go-redis": "cache"}
+	reserved := map[string]bool{"repo": true}
+
+	importMap := importAliases(importPaths, packageNames, reserved)
+
+	assert.Equal(t, map[string]string{
+		"github.com/foo/bar/a/service": "service",
+		"github.com/foo/bar/b/service": "service2",
+		"github.com/foo/bar/error":     "error2",
+		"github.com/foo/bar/go-redis":  "cache",
+		"github.com/foo/bar/repo":      "repo2",
+		"github.com/foo/bar/type":      "typepkg",
+		"github.com/foo/bar/wire":      "wire2",
+	}, importMap)
+
+	// The aliases do not depend on the order of the paths
+	for i, j := 0, len(importPaths)-1; i < j; i, j = i+1, j-1 {
+		importPaths[i], importPaths[j] = importPaths[j], importPaths[i]
+	}
+	assert.Equal(t, importMap, importAliases(importPaths, packageNames, reserved))
+}
+
+func Test_omitAliases(t *testing.T) {
+	t.Parallel()
+
+	imports := []*models.ImportTemplate{
+		{Alias: "service", Path: "github.com/foo/bar/service"},
+		{Alias: "service2", Path: "github.com/foo/baz/service"},
+		{Alias: "redis", Path: "github.com/go-redis/redis"},
+	}
+
+	omitAliases(imports, map[string]string{"github.com/foo/bar/service": "service", "github.com/foo/baz/service": "service"})
+
+	assert.Equal(t, []*models.ImportTemplate{
+		{Alias: "", Path: "github.com/foo/bar/service"},
+		{Alias: "service2", Path: "github.com/foo/baz/service"},
+		{Alias: "redis", Path: "github.com/go-redis/redis"},
+	}, imports)
+}
+
+func Test_backendTemplate_noSets(t *testing.T) {
+	t.Parallel()
+
+	// A location without sets gets a file without imports, an unused import would not compile
+	for _, name := range []string{BackendFx, BackendDo, BackendDig} {
+		t.Run(name, func(tt *testing.T) {
+			tt.Parallel()
+
+			selected, err := getBackend(name)
+			assert.NoError(tt, err)
+
+			tmpl, err := template.New(name).Funcs(templateFuncs(nil)).Parse(selected.template)
+			assert.NoError(tt, err)
+
+			var buf bytes.Buffer
+			assert.NoError(tt, tmpl.Execute(&buf, &models.WireSetGenTemplateModel{PackageName: "wire"}))
+			assert.NoError(tt, validateGeneratedFile(path.Join("wire", selected.fileName), "wire", buf.Bytes(), nil, nil))
+		})
+	}
+}
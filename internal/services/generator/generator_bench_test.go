@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_cache "github.com/graphzc/wiresetgen/internal/repositories/cache/mock"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+)
+
+// Repository that reports every file as matching, the scan then parses every file like before the pre-filter
+type noPrefilterRepository struct {
+	fileRepo.Repository
+}
+
+func (r *noPrefilterRepository) ContainsAny(filePath string, markers []string) (bool, error) {
+	return true, nil
+}
+
+// For write a synthetic module where only one file per package has an annotation
+func writeSyntheticTree(b *testing.B, packages int, filesPerPackage int) string {
+	b.Helper()
+
+	root := b.TempDir()
+	filler := strings.Repeat("\tvalue := compute(input)\n\tif value > 0 {\n\t\treturn value\n\t}\n", 40)
+
+	write := func(filePath string, content string) {
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(root, "go.mod"), "module github.com/foo/bench\n\ngo 1.23\n")
+	write(filepath.Join(root, "wire", "wire.go"), "//go:build wireinject\n\npackage wire\n")
+
+	for p := range packages {
+		packageName := fmt.Sprintf("pkg%d", p)
+		for f := range filesPerPackage {
+			content := fmt.Sprintf("package %s\n\nfunc helper%d(input int) int {\n%s\treturn 0\n}\n", packageName, f, filler)
+			if f == 0 {
+				content += "\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n"
+			}
+
+			write(filepath.Join(root, "internal", packageName, fmt.Sprintf("file%d.go", f)), content)
+		}
+	}
+
+	return root
+}
+
+func BenchmarkScanProject(b *testing.B) {
+	root := writeSyntheticTree(b, 100, 50)
+
+	benchmarks := []struct {
+		name           string
+		fileRepository fileRepo.Repository
+	}{
+		{
+			name:           "prefilter",
+			fileRepository: fileRepo.NewFileRepository(root),
+		},
+		{
+			name:           "no_prefilter",
+			fileRepository: &noPrefilterRepository{Repository: fileRepo.NewFileRepository(root)},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(bb *testing.B) {
+			service := NewGenerateService(bm.fileRepository, mock_cache.NewRepository(bb))
+			options := &models.GenerateOptions{NoCache: true, Jobs: 1}
+
+			bb.ReportAllocs()
+			bb.ResetTimer()
+
+			for range bb.N {
+				scan, err := service.ScanProject(context.Background(), options)
+				if err != nil {
+					bb.Fatal(err)
+				}
+				if len(scan.SetInfos) != 100 {
+					bb.Fatalf("expected 100 set infos, got %d", len(scan.SetInfos))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+const BuildFuncName = "Build"
+
+// Zero values of the predeclared types that are not nil
+var predeclaredZeroValues = map[string]string{
+	"bool": "false", "string": `""`,
+	"int": "0", "int8": "0", "int16": "0", "int32": "0", "int64": "0",
+	"uint": "0", "uint8": "0", "uint16": "0", "uint32": "0", "uint64": "0", "uintptr": "0",
+	"byte": "0", "rune": "0", "float32": "0", "float64": "0", "complex64": "0", "complex128": "0",
+}
+
+// For order the providers so every provider comes after the providers of its inputs
+// Providers are visited by set name, then in scan order, so the output is stable
+// A provider with bind also provides the interface, its value is passed where the interface is an input
+func buildOrder(setInfos []*models.WireSetInfo) ([]*models.WireSetInfo, error) {
+	sorted := make([]*models.WireSetInfo, len(setInfos))
+	copy(sorted, setInfos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SetName < sorted[j].SetName
+	})
+
+	providers := make(map[string]*models.WireSetInfo)
+	for _, setInfo := range sorted {
+		providedType := ProvidedType(setInfo.ResultTypes)
+		if providedType == "" {
+			return nil, fmt.Errorf("%w: %s.%s", ErrMissingSignature, setInfo.PackageName, setInfo.FunctionName)
+		}
+
+		for _, typeName := range []string{providedType, setInfo.Bind} {
+			if typeName == "" {
+				continue
+			}
+
+			if provider, exists := providers[typeName]; exists {
+				return nil, fmt.Errorf("%w: %s by %s.%s and %s.%s", ErrDuplicateProvider, typeName,
+					provider.PackageName, provider.FunctionName, setInfo.PackageName, setInfo.FunctionName)
+			}
+			providers[typeName] = setInfo
+		}
+	}
+
+	ordered := make([]*models.WireSetInfo, 0, len(sorted))
+	done := make(map[*models.WireSetInfo]bool)
+	visiting := make([]*models.WireSetInfo, 0)
+
+	var visit func(setInfo *models.WireSetInfo) error
+	visit = func(setInfo *models.WireSetInfo) error {
+		if done[setInfo] {
+			return nil
+		}
+
+		for i, visited := range visiting {
+			if visited == setInfo {
+				cycle := make([]string, 0, len(visiting)-i+1)
+				for _, provider := range append(visiting[i:], setInfo) {
+					cycle = append(cycle, provider.PackageName+"."+provider.FunctionName)
+				}
+
+				return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+			}
+		}
+
+		visiting = append(visiting, setInfo)
+		for _, paramType := range setInfo.ParamTypes {
+			if provider, exists := providers[paramType]; exists {
+				if err := visit(provider); err != nil {
+					return err
+				}
+			}
+		}
+		visiting = visiting[:len(visiting)-1]
+
+		done[setInfo] = true
+		ordered = append(ordered, setInfo)
+
+		return nil
+	}
+
+	for _, setInfo := range sorted {
+		if err := visit(setInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// For plan the Build function of a location and the imports it uses
+// The returned values are the provided types no other provider consumes
+func newBuildTemplateModel(
+	setInfos []*models.WireSetInfo,
+	localImportPath string,
+	importMap map[string]string,
+) (*models.BuildTemplateModel, []*models.ImportTemplate, error) {
+	ordered, err := buildOrder(setInfos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imports := make([]*models.ImportTemplate, 0)
+	if len(ordered) == 0 {
+		return nil, imports, nil
+	}
+
+	consumed := make(map[string]bool)
+	for _, setInfo := range ordered {
+		for _, paramType := range setInfo.ParamTypes {
+			consumed[paramType] = true
+		}
+	}
+
+	rootTypes := make([]string, 0)
+	for _, setInfo := range ordered {
+		if providedType := ProvidedType(setInfo.ResultTypes); !consumed[providedType] && (setInfo.Bind == "" || !consumed[setInfo.Bind]) {
+			rootTypes = append(rootTypes, providedType)
+		}
+	}
+
+	// Variadic inputs are optional, the provider is called without them
+	paramTypes := make([]string, 0)
+	for _, paramType := range UnprovidedTypes(ordered) {
+		if !strings.HasPrefix(paramType, "...") {
+			paramTypes = append(paramTypes, paramType)
+		}
+	}
+
+	hasCleanup := HasResult(ordered, "func()")
+	hasError := HasResult(ordered, "error")
+
+	// Variables never shadow an import or a provider of the location package
+	usedNames := map[string]bool{"err": true, BuildFuncName: true}
+	for importPath, alias := range importMap {
+		if importPath != localImportPath {
+			usedNames[alias] = true
+		}
+	}
+	for _, setInfo := range ordered {
+		if setInfo.ImportPath == localImportPath {
+			usedNames[setInfo.FunctionName] = true
+		}
+	}
+
+	usedImports := make(map[string]bool)
+	qualify := func(scannedType string) string {
+		for _, importPath := range TypeImportPaths([]string{scannedType}) {
+			usedImports[importPath] = true
+		}
+
+		return SourceType(scannedType, localImportPath, importMap)
+	}
+
+	model := &models.BuildTemplateModel{
+		Name:         BuildFuncName,
+		Params:       make([]*models.InjectorParam, 0, len(paramTypes)),
+		Results:      make([]string, 0, len(rootTypes)+2),
+		Steps:        make([]*models.BuildStep, 0, len(ordered)),
+		Returns:      make([]string, 0, len(rootTypes)+2),
+		ErrorReturns: make([]string, 0, len(rootTypes)+2),
+	}
+
+	values := make(map[string]string)
+	for _, paramType := range paramTypes {
+		name := TypeVarName(paramType, usedNames)
+		values[paramType] = name
+		model.Params = append(model.Params, &models.InjectorParam{
+			Name: name,
+			Type: qualify(paramType),
+		})
+	}
+
+	cleanups := make([]string, 0)
+	for _, setInfo := range ordered {
+		args := make([]string, 0, len(setInfo.ParamTypes))
+		for _, paramType := range providerParamTypes(setInfo) {
+			args = append(args, values[paramType])
+		}
+
+		call := setInfo.FunctionName
+		if setInfo.ImportPath != localImportPath {
+			usedImports[setInfo.ImportPath] = true
+			call = importMap[setInfo.ImportPath] + "." + call
+		}
+
+		step := &models.BuildStep{
+			Vars: make([]string, 0, len(setInfo.ResultTypes)),
+			Call: call + "(" + strings.Join(args, ", ") + ")",
+		}
+
+		// The cleanup of a failed provider is not called, only the ones before it
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			step.Cleanups = append(step.Cleanups, cleanups[i])
+		}
+
+		for _, resultType := range setInfo.ResultTypes {
+			switch resultType {
+			case "func()":
+				name := TypeVarName("cleanup", usedNames)
+				cleanups = append(cleanups, name)
+				step.Vars = append(step.Vars, name)
+			case "error":
+				step.Error = true
+				step.Vars = append(step.Vars, "err")
+			default:
+				name := TypeVarName(resultType, usedNames)
+				values[resultType] = name
+				if setInfo.Bind != "" {
+					values[setInfo.Bind] = name
+				}
+				step.Vars = append(step.Vars, name)
+			}
+		}
+
+		model.Steps = append(model.Steps, step)
+	}
+
+	for _, rootType := range rootTypes {
+		sourceType := qualify(rootType)
+		model.Results = append(model.Results, sourceType)
+		model.Returns = append(model.Returns, values[rootType])
+		model.ErrorReturns = append(model.ErrorReturns, zeroValue(rootType, sourceType))
+	}
+
+	if hasCleanup {
+		model.Results = append(model.Results, "func()")
+		model.ErrorReturns = append(model.ErrorReturns, "nil")
+
+		// The cleanups run in the reverse order of the providers
+		cleanup := cleanups[0]
+		if len(cleanups) > 1 {
+			lines := make([]string, 0, len(cleanups))
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				lines = append(lines, cleanups[i]+"()")
+			}
+			cleanup = "func() {\n" + strings.Join(lines, "\n") + "\n}"
+		}
+		model.Returns = append(model.Returns, cleanup)
+	}
+
+	if hasError {
+		model.Results = append(model.Results, "error")
+		model.Returns = append(model.Returns, "nil")
+		model.ErrorReturns = append(model.ErrorReturns, "err")
+	}
+
+	for importPath := range usedImports {
+		if importPath == localImportPath {
+			continue
+		}
+
+		imports = append(imports, &models.ImportTemplate{
+			Alias: importMap[importPath],
+			Path:  importPath,
+		})
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Path < imports[j].Path
+	})
+
+	return model, imports, nil
+}
+
+// For write the zero value of a type, a named type that may be a struct or an interface uses *new(T)
+func zeroValue(scannedType string, sourceType string) string {
+	if zero, ok := predeclaredZeroValues[scannedType]; ok {
+		return zero
+	}
+
+	for _, prefix := range []string{"*", "[]", "map[", "chan ", "chan<- ", "<-chan ", "func("} {
+		if strings.HasPrefix(scannedType, prefix) {
+			return "nil"
+		}
+	}
+
+	switch scannedType {
+	case "error", "any", "interface{}":
+		return "nil"
+	}
+
+	return "*new(" + sourceType + ")"
+}
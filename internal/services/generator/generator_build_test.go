@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestSetInfos() []*models.WireSetInfo {
+	return []*models.WireSetInfo{
+		{
+			PackageName:  "app",
+			SetName:      "App",
+			FunctionName: "NewApp",
+			ImportPath:   "github.com/foo/bar/app",
+			ParamTypes:   []string{"*github.com/foo/bar/db.DB", "github.com/foo/bar/db.Cache"},
+			ResultTypes:  []string{"*github.com/foo/bar/app.App", "error"},
+		},
+		{
+			PackageName:  "db",
+			SetName:      "Infra",
+			FunctionName: "NewDB",
+			ImportPath:   "github.com/foo/bar/db",
+			ParamTypes:   []string{"github.com/foo/bar/config.Config"},
+			ResultTypes:  []string{"*github.com/foo/bar/db.DB", "func()", "error"},
+		},
+		{
+			PackageName:  "db",
+			SetName:      "Infra",
+			FunctionName: "NewCache",
+			ImportPath:   "github.com/foo/bar/db",
+			ParamTypes:   []string{"*github.com/foo/bar/db.DB", "...string"},
+			ResultTypes:  []string{"github.com/foo/bar/db.Cache", "func()"},
+		},
+	}
+}
+
+func Test_buildOrder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setInfos      func() []*models.WireSetInfo
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:     "Dependencies first",
+			setInfos: buildTestSetInfos,
+			expected: []string{"NewDB", "NewCache", "NewApp"},
+		},
+		{
+			name: "Cycle",
+			setInfos: func() []*models.WireSetInfo {
+				setInfos := buildTestSetInfos()
+				setInfos[1].ParamTypes = []string{"*github.com/foo/bar/app.App"}
+				return setInfos
+			},
+			expectedError: ErrDependencyCycle,
+		},
+		{
+			name: "Duplicate provider",
+			setInfos: func() []*models.WireSetInfo {
+				setInfos := buildTestSetInfos()
+				setInfos[2].ResultTypes = []string{"*github.com/foo/bar/db.DB"}
+				return setInfos
+			},
+			expectedError: ErrDuplicateProvider,
+		},
+		{
+			name: "Bind to a provided type",
+			setInfos: func() []*models.WireSetInfo {
+				setInfos := buildTestSetInfos()
+				setInfos[2].Bind = "*github.com/foo/bar/db.DB"
+				return setInfos
+			},
+			expectedError: ErrDuplicateProvider,
+		},
+		{
+			name: "Missing signature",
+			setInfos: func() []*models.WireSetInfo {
+				setInfos := buildTestSetInfos()
+				setInfos[0].ResultTypes = nil
+				return setInfos
+			},
+			expectedError: ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ordered, err := buildOrder(tt.setInfos())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			functionNames := make([]string, 0, len(ordered))
+			for _, setInfo := range ordered {
+				functionNames = append(functionNames, setInfo.FunctionName)
+			}
+			assert.Equal(t, tt.expected, functionNames)
+		})
+	}
+}
+
+func Test_newBuildTemplateModel(t *testing.T) {
+	t.Parallel()
+
+	setInfos := buildTestSetInfos()
+	importMap := importAliases([]string{"github.com/foo/bar/app", "github.com/foo/bar/db", "github.com/foo/bar/config"}, nil, nil)
+
+	model, imports, err := newBuildTemplateModel(setInfos, "github.com/foo/bar/app", importMap)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.ImportTemplate{
+		{Alias: "config", Path: "github.com/foo/bar/config"},
+		{Alias: "db", Path: "github.com/foo/bar/db"},
+	}, imports)
+	assert.Equal(t, &models.BuildTemplateModel{
+		Name:    "Build",
+		Params:  []*models.InjectorParam{{Name: "config2", Type: "config.Config"}},
+		Results: []string{"*App", "func()", "error"},
+		Steps: []*models.BuildStep{
+			{Vars: []string{"db2", "cleanup", "err"}, Call: "db.NewDB(config2)", Error: true},
+			{Vars: []string{"cache", "cleanup2"}, Call: "db.NewCache(db2)", Cleanups: []string{"cleanup"}},
+			{Vars: []string{"app", "err"}, Call: "NewApp(db2, cache)", Error: true, Cleanups: []string{"cleanup2", "cleanup"}},
+		},
+		Returns:      []string{"app", "func() {\ncleanup2()\ncleanup()\n}", "nil"},
+		ErrorReturns: []string{"nil", "nil", "err"},
+	}, model)
+}
+
+func Test_newBuildTemplateModel_bind(t *testing.T) {
+	t.Parallel()
+
+	setInfos := []*models.WireSetInfo{
+		{
+			PackageName:  "app",
+			SetName:      "App",
+			FunctionName: "NewApp",
+			ImportPath:   "github.com/foo/bar/app",
+			ParamTypes:   []string{"github.com/foo/bar/app.Store"},
+			ResultTypes:  []string{"*github.com/foo/bar/app.App"},
+		},
+		{
+			PackageName:  "db",
+			SetName:      "Infra",
+			FunctionName: "NewRepo",
+			ImportPath:   "github.com/foo/bar/db",
+			ResultTypes:  []string{"*github.com/foo/bar/db.Repo"},
+			Bind:         "github.com/foo/bar/app.Store",
+		},
+	}
+	importMap := importAliases([]string{"github.com/foo/bar/app", "github.com/foo/bar/db"}, nil, nil)
+
+	model, _, err := newBuildTemplateModel(setInfos, "github.com/foo/bar/app", importMap)
+
+	// The interface is not an input of Build and the bound provider is not a result
+	assert.NoError(t, err)
+	assert.Empty(t, model.Params)
+	assert.Equal(t, []string{"*App"}, model.Results)
+	assert.Equal(t, []*models.BuildStep{
+		{Vars: []string{"repo"}, Call: "db.NewRepo()"},
+		{Vars: []string{"app"}, Call: "NewApp(repo)"},
+	}, model.Steps)
+}
+
+func Test_zeroValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scannedType string
+		sourceType  string
+		expected    string
+	}{
+		{scannedType: "*github.com/foo/bar/app.App", sourceType: "*app.App", expected: "nil"},
+		{scannedType: "[]string", sourceType: "[]string", expected: "nil"},
+		{scannedType: "map[string]int", sourceType: "map[string]int", expected: "nil"},
+		{scannedType: "string", sourceType: "string", expected: `""`},
+		{scannedType: "int64", sourceType: "int64", expected: "0"},
+		{scannedType: "github.com/foo/bar/app.App", sourceType: "app.App", expected: "*new(app.App)"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.scannedType, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, zeroValue(tt.scannedType, tt.sourceType))
+		})
+	}
+}
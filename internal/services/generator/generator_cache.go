@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	cacheRepo "github.com/graphzc/wiresetgen/internal/repositories/cache"
+	"github.com/sirupsen/logrus"
+)
+
+// Bump when the extraction changes, so caches written by older versions are dropped
+const scanCacheVersion = 9
+
+func newScanCache(moduleName string) *models.ScanCache {
+	return &models.ScanCache{
+		Version:    scanCacheVersion,
+		ModuleName: moduleName,
+		Files:      make(map[string]*models.CachedFileScan),
+	}
+}
+
+// For load the previous scan cache, an unusable cache is treated as empty
+func (g *generatorServiceImpl) loadScanCache(moduleName string, options *models.GenerateOptions) *models.ScanCache {
+	if options.NoCache {
+		return newScanCache(moduleName)
+	}
+
+	scanCache, err := g.cacheRepository.LoadScanCache()
+	if err != nil {
+		if !errors.Is(err, cacheRepo.ErrCacheNotFound) && options.Verbose {
+			logrus.Warn("Cannot load scan cache, scanning every file:", err)
+		}
+
+		return newScanCache(moduleName)
+	}
+
+	// Import paths depend on the module name, so the cache is useless after a rename
+	if scanCache.Version != scanCacheVersion || scanCache.ModuleName != moduleName || scanCache.Files == nil {
+		return newScanCache(moduleName)
+	}
+
+	return scanCache
+}
+
+// For scan the file unless the cache has an entry with the same size and modification time or the same content hash
+// Return the file scan and the cache entry to keep for the next run
+func (g *generatorServiceImpl) scanFileWithCache(
+	moduleName string,
+	filePath string,
+	previousCache *models.ScanCache,
+	verbose bool,
+) (*models.FileScan, *models.CachedFileScan, error) {
+	stat, err := g.fileRepository.StatFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cached := previousCache.Files[filePath]
+	if cached != nil && cached.Size == stat.Size && cached.ModTime.Equal(stat.ModTime) {
+		return cached.FileScan, cached, nil
+	}
+
+	// Files without any marker have nothing to extract, checking is cheaper than hashing
+	matched, err := g.fileRepository.ContainsAny(filePath, scanMarkers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !matched {
+		return &models.FileScan{}, &models.CachedFileScan{
+			Size:     stat.Size,
+			ModTime:  stat.ModTime,
+			FileScan: &models.FileScan{},
+		}, nil
+	}
+
+	fileContent, err := g.fileRepository.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash := sha256.Sum256([]byte(fileContent))
+	entry := &models.CachedFileScan{
+		Size:    stat.Size,
+		ModTime: stat.ModTime,
+		Hash:    hex.EncodeToString(hash[:]),
+	}
+
+	// Touched but unchanged files keep the cached scan
+	if cached != nil && cached.Hash == entry.Hash {
+		entry.FileScan = cached.FileScan
+	} else {
+		fileScan, err := scanFileContent(moduleName, filePath, fileContent, verbose)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry.FileScan = fileScan
+	}
+
+	return entry.FileScan, entry, nil
+}
+
+func (g *generatorServiceImpl) CleanCache() error {
+	return g.cacheRepository.Clean()
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_cache "github.com/graphzc/wiresetgen/internal/repositories/cache/mock"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scanFileWithCache(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fileContent := "package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n"
+
+	cachedScan := &models.FileScan{
+		SetInfos: []*models.WireSetInfo{{SetName: "Cached", FunctionName: "NewCached"}},
+	}
+
+	testCases := []struct {
+		name           string
+		cached         *models.CachedFileScan
+		stat           *models.FileStat
+		expectRead     bool
+		expectedSetRef string
+	}{
+		{
+			name:           "Same size and modification time uses the cache",
+			cached:         &models.CachedFileScan{Size: 10, ModTime: modTime, Hash: "old", FileScan: cachedScan},
+			stat:           &models.FileStat{Size: 10, ModTime: modTime},
+			expectRead:     false,
+			expectedSetRef: "Cached",
+		},
+		{
+			name:           "Changed file is scanned again",
+			cached:         &models.CachedFileScan{Size: 10, ModTime: modTime, Hash: "old", FileScan: cachedScan},
+			stat:           &models.FileStat{Size: int64(len(fileContent)), ModTime: modTime.Add(time.Second)},
+			expectRead:     true,
+			expectedSetRef: "Service",
+		},
+		{
+			name:           "Uncached file is scanned",
+			cached:         nil,
+			stat:           &models.FileStat{Size: int64(len(fileContent)), ModTime: modTime},
+			expectRead:     true,
+			expectedSetRef: "Service",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			fileRepository := mock_files.NewRepository(tt)
+			fileRepository.EXPECT().StatFile("service/service.go").Return(tc.stat, nil)
+			if tc.expectRead {
+				fileRepository.EXPECT().ContainsAny("service/service.go", scanMarkers).Return(true, nil)
+				fileRepository.EXPECT().ReadFile("service/service.go").Return(fileContent, nil)
+			}
+
+			service := &generatorServiceImpl{
+				fileRepository:  fileRepository,
+				cacheRepository: mock_cache.NewRepository(tt),
+			}
+
+			previousCache := newScanCache("github.com/foo/bar")
+			if tc.cached != nil {
+				previousCache.Files["service/service.go"] = tc.cached
+			}
+
+			fileScan, cacheEntry, err := service.scanFileWithCache("github.com/foo/bar", "service/service.go", previousCache, false)
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.expectedSetRef, fileScan.SetInfos[0].SetName)
+			assert.Equal(tt, fileScan, cacheEntry.FileScan)
+			assert.Equal(tt, tc.stat.Size, cacheEntry.Size)
+		})
+	}
+}
+
+func Test_scanFileWithCache_sameHash(t *testing.T) {
+	t.Parallel()
+
+	fileContent := "package service\n"
+	modTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().StatFile("service/service.go").Return(&models.FileStat{Size: 16, ModTime: modTime.Add(time.Hour)}, nil)
+	fileRepository.EXPECT().ContainsAny("service/service.go", scanMarkers).Return(true, nil)
+	fileRepository.EXPECT().ReadFile("service/service.go").Return(fileContent, nil)
+
+	service := &generatorServiceImpl{
+		fileRepository:  fileRepository,
+		cacheRepository: mock_cache.NewRepository(t),
+	}
+
+	cachedScan := &models.FileScan{
+		SetInfos: []*models.WireSetInfo{{SetName: "Cached", FunctionName: "NewCached"}},
+	}
+
+	previousCache := newScanCache("github.com/foo/bar")
+	previousCache.Files["service/service.go"] = &models.CachedFileScan{
+		Size:     16,
+		ModTime:  modTime,
+		Hash:     "91c374ed6119537e01ac79b45619239cff7d53b3a4fa828f82ff1d4f93fa04f2",
+		FileScan: cachedScan,
+	}
+
+	fileScan, cacheEntry, err := service.scanFileWithCache("github.com/foo/bar", "service/service.go", previousCache, false)
+
+	assert.NoError(t, err)
+	assert.Same(t, cachedScan, fileScan)
+	assert.Equal(t, modTime.Add(time.Hour), cacheEntry.ModTime)
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"gopkg.in/yaml.v3"
+)
+
+// A project without a config file uses the defaults
+func (g *generatorServiceImpl) LoadConfig() (*models.Config, error) {
+	configFile, err := g.fileRepository.GetConfigFile()
+	if err != nil {
+		if errors.Is(err, fileRepo.ErrFileNotFound) {
+			return &models.Config{}, nil
+		}
+
+		return nil, err
+	}
+
+	return ParseConfig(configFile)
+}
+
+// For list the Go files of the project without the excluded directories
+func (g *generatorServiceImpl) ListGoFiles(ctx context.Context, jobs int) ([]string, error) {
+	config, err := g.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	goFiles, err := g.fileRepository.ListAllGoFiles(ctx, jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.Exclude) == 0 {
+		return goFiles, nil
+	}
+
+	includedFiles := make([]string, 0, len(goFiles))
+	for _, goFile := range goFiles {
+		if !isExcluded(goFile, config.Exclude) {
+			includedFiles = append(includedFiles, goFile)
+		}
+	}
+
+	return includedFiles, nil
+}
+
+// For read and check the content of a config file, the analyzer reads it too
+func ParseConfig(configFile string) (*models.Config, error) {
+	config := &models.Config{}
+
+	decoder := yaml.NewDecoder(strings.NewReader(configFile))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfigFile, err)
+	}
+
+	for _, pattern := range config.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: exclude pattern %q: %v", ErrInvalidConfigFile, pattern, err)
+		}
+	}
+
+	if err := validateNaming(&config.Naming); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfigFile, err)
+	}
+
+	if err := ValidateStyle(config.Style); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfigFile, err)
+	}
+
+	if _, err := getBackend(config.Backend); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfigFile, err)
+	}
+	for directory, name := range config.Backends {
+		if _, err := getBackend(name); err != nil || name == "" {
+			return nil, fmt.Errorf("%w: backend of %s: %v", ErrInvalidConfigFile, directory, err)
+		}
+	}
+
+	for directory, value := range config.Constraints {
+		if err := ValidateConstraint(value); err != nil {
+			return nil, fmt.Errorf("%w: constraint of %s: %w", ErrInvalidConfigFile, directory, err)
+		}
+	}
+
+	for directory, profiles := range config.Profiles {
+		for _, profile := range profiles {
+			if err := ValidateProfile(profile); err != nil {
+				return nil, fmt.Errorf("%w: profile of %s: %w", ErrInvalidConfigFile, directory, err)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// A pattern without a slash matches any directory name, like vendor or testdata
+// A pattern with a slash matches a directory path from the project root, like internal/legacy
+func isExcluded(filePath string, patterns []string) bool {
+	directories := strings.Split(filepath.ToSlash(filepath.Dir(filePath)), "/")
+
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+
+		if !strings.Contains(pattern, "/") {
+			for _, directory := range directories {
+				if matched, _ := path.Match(pattern, directory); matched {
+					return true
+				}
+			}
+			continue
+		}
+
+		depth := strings.Count(pattern, "/") + 1
+		if depth > len(directories) {
+			continue
+		}
+
+		if matched, _ := path.Match(pattern, strings.Join(directories[:depth], "/")); matched {
+			return true
+		}
+	}
+
+	return false
+}
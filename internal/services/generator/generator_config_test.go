@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		configFile     string
+		expectedConfig *models.Config
+		expectedError  error
+	}{
+		{
+			name:           "Empty file",
+			configFile:     "",
+			expectedConfig: &models.Config{},
+		},
+		{
+			name:           "Exclude",
+			configFile:     "exclude:\n  - vendor\n  - internal/legacy\n",
+			expectedConfig: &models.Config{Exclude: []string{"vendor", "internal/legacy"}},
+		},
+		{
+			name:           "Backend",
+			configFile:     "backend: fx\n",
+			expectedConfig: &models.Config{Backend: "fx"},
+		},
+		{
+			name:          "Unknown backend",
+			configFile:    "backend: spring\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:           "Location backends",
+			configFile:     "backends:\n  cmd/worker: dig\n",
+			expectedConfig: &models.Config{Backends: map[string]string{"cmd/worker": "dig"}},
+		},
+		{
+			name:          "Unknown location backend",
+			configFile:    "backends:\n  cmd/worker: guice\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:           "Location profiles",
+			configFile:     "profiles:\n  cmd/api: [dev, prod]\n",
+			expectedConfig: &models.Config{Profiles: map[string][]string{"cmd/api": {"dev", "prod"}}},
+		},
+		{
+			name:          "Profile is not a build tag",
+			configFile:    "profiles:\n  cmd/api: [dev.local]\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:           "Location constraints",
+			configFile:     "constraints:\n  cmd/desktop: windows || darwin\n",
+			expectedConfig: &models.Config{Constraints: map[string]string{"cmd/desktop": "windows || darwin"}},
+		},
+		{
+			name:          "Constraint is not a build expression",
+			configFile:    "constraints:\n  cmd/desktop: windows ||\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:           "Directive style",
+			configFile:     "style: directive\n",
+			expectedConfig: &models.Config{Style: "directive"},
+		},
+		{
+			name:          "Unknown style",
+			configFile:    "style: javadoc\n",
+			expectedError: ErrUnknownStyle,
+		},
+		{
+			name:           "Naming",
+			configFile:     "naming:\n  strategy: prefix\n  affix: Provide\n  export: unexported\n",
+			expectedConfig: &models.Config{Naming: models.NamingConfig{Strategy: "prefix", Affix: "Provide", Export: "unexported"}},
+		},
+		{
+			name:          "Unknown naming strategy",
+			configFile:    "naming:\n  strategy: infix\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:          "Naming affix is not an identifier",
+			configFile:    "naming:\n  affix: my-set\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:          "Unknown key",
+			configFile:    "excludes:\n  - vendor\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+		{
+			name:          "Invalid pattern",
+			configFile:    "exclude:\n  - \"[\"\n",
+			expectedError: ErrInvalidConfigFile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			config, err := ParseConfig(tc.configFile)
+
+			assert.Equal(tt, tc.expectedConfig, config)
+			assert.True(tt, errors.Is(err, tc.expectedError), "error %v", err)
+		})
+	}
+}
+
+func Test_isExcluded(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		filePath string
+		patterns []string
+		expected bool
+	}{
+		{name: "Directory name at any depth", filePath: "pkg/analyzer/testdata/src/a.go", patterns: []string{"testdata"}, expected: true},
+		{name: "Directory name glob", filePath: "internal/legacy_v1/a.go", patterns: []string{"legacy_*"}, expected: true},
+		{name: "Directory path from the root", filePath: "internal/legacy/user/a.go", patterns: []string{"internal/legacy"}, expected: true},
+		{name: "Directory path elsewhere", filePath: "pkg/internal/legacy/a.go", patterns: []string{"internal/legacy"}, expected: false},
+		{name: "File name is not matched", filePath: "service/vendor.go", patterns: []string{"vendor.go"}, expected: false},
+		{name: "Root file", filePath: "main.go", patterns: []string{"vendor"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expected, isExcluded(tc.filePath, tc.patterns))
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package generator
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// The GOOS and GOARCH values a file name suffix constrains the file to, like the go command reads them
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+		"illumos": true, "ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true,
+		"openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+		"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+		"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true,
+		"riscv": true, "riscv64": true, "s390": true, "s390x": true, "sparc": true, "sparc64": true,
+		"wasm": true,
+	}
+)
+
+// For get the constraint a source file is built with, from its //go:build line and
+// its _GOOS, _GOARCH or _GOOS_GOARCH file name suffix, empty when it is always built
+func FileConstraint(filePath string, fileContent string) string {
+	parts := make([]string, 0, 3)
+
+	for _, line := range strings.Split(fileContent, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+
+		if constraint.IsGoBuild(line) {
+			if expr, err := constraint.Parse(line); err == nil {
+				parts = append(parts, expr.String())
+			}
+			break
+		}
+	}
+
+	// The name is cut at the first dot like the go command does, e.g. cache_linux_amd64.go
+	name, _, _ := strings.Cut(filepath.Base(filePath), ".")
+	elements := strings.Split(name, "_")
+	if n := len(elements); n >= 3 && knownOS[elements[n-2]] && knownArch[elements[n-1]] {
+		parts = append(parts, elements[n-2], elements[n-1])
+	} else if n >= 2 && (knownOS[elements[n-1]] || knownArch[elements[n-1]]) {
+		parts = append(parts, elements[n-1])
+	}
+
+	return andConstraints(parts...)
+}
+
+// For join constraint expressions with &&, empty and repeated expressions are left out
+func andConstraints(exprs ...string) string {
+	var result constraint.Expr
+	seen := make(map[string]bool)
+	for _, value := range exprs {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+
+		expr, err := constraint.Parse("//go:build " + value)
+		if err != nil {
+			continue
+		}
+
+		if result == nil {
+			result = expr
+		} else {
+			result = &constraint.AndExpr{X: result, Y: expr}
+		}
+	}
+
+	if result == nil {
+		return ""
+	}
+
+	return result.String()
+}
+
+// For check that a constraint of the config is a valid //go:build expression
+func ValidateConstraint(value string) error {
+	if _, err := constraint.Parse("//go:build " + value); err != nil || strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidConstraint, value)
+	}
+
+	return nil
+}
+
+// For get the constraint of an injector without the wireinject tag, e.g. wireinject && linux becomes linux
+// The generated file is built without the tag, so only the rest of the constraint applies to it
+func injectorConstraint(line string) string {
+	expr, err := constraint.Parse(line)
+	if err != nil {
+		return ""
+	}
+
+	rest, value := assumeTag(expr, "wireinject")
+	if rest == nil || !value {
+		return ""
+	}
+
+	return rest.String()
+}
+
+// For simplify an expression where a tag is set, a nil expression is the constant value
+func assumeTag(expr constraint.Expr, tag string) (constraint.Expr, bool) {
+	switch expr := expr.(type) {
+	case *constraint.TagExpr:
+		if expr.Tag == tag {
+			return nil, true
+		}
+
+		return expr, true
+	case *constraint.NotExpr:
+		inner, value := assumeTag(expr.X, tag)
+		if inner == nil {
+			return nil, !value
+		}
+
+		return &constraint.NotExpr{X: inner}, true
+	case *constraint.AndExpr:
+		x, xValue := assumeTag(expr.X, tag)
+		y, yValue := assumeTag(expr.Y, tag)
+		switch {
+		case (x == nil && !xValue) || (y == nil && !yValue):
+			return nil, false
+		case x == nil:
+			return y, yValue
+		case y == nil:
+			return x, xValue
+		}
+
+		return &constraint.AndExpr{X: x, Y: y}, true
+	case *constraint.OrExpr:
+		x, xValue := assumeTag(expr.X, tag)
+		y, yValue := assumeTag(expr.Y, tag)
+		switch {
+		case (x == nil && xValue) || (y == nil && yValue):
+			return nil, true
+		case x == nil:
+			return y, yValue
+		case y == nil:
+			return x, xValue
+		}
+
+		return &constraint.OrExpr{X: x, Y: y}, true
+	}
+
+	return expr, true
+}
+
+// For pick the constraint of a location, the config of its directory wins over the injector file
+func locationConstraint(wireGenLocation *models.WireGenLocation, config *models.Config) string {
+	if value := config.Constraints[filepath.ToSlash(wireGenLocation.DirectoryPath)]; value != "" {
+		return value
+	}
+
+	return wireGenLocation.Constraint
+}
+
+// For merge the providers that are declared once per platform, e.g. NewCache in cache_linux.go
+// and cache_windows.go, into one provider built when any of its files is
+func mergeConstrainedSetInfos(setInfos []*models.WireSetInfo) []*models.WireSetInfo {
+	merged := make([]*models.WireSetInfo, 0, len(setInfos))
+	byFunction := make(map[string]*models.WireSetInfo)
+	for _, setInfo := range setInfos {
+		key := setInfo.SetName + " " + setInfo.ImportPath + "." + setInfo.FunctionName
+		previous, exists := byFunction[key]
+		if !exists || previous.Constraint == "" || setInfo.Constraint == "" {
+			byFunction[key] = setInfo
+			merged = append(merged, setInfo)
+			continue
+		}
+
+		// The first declaration is kept and built with either constraint
+		mergedInfo := *previous
+		mergedInfo.Constraint = orConstraints(previous.Constraint, setInfo.Constraint)
+		byFunction[key] = &mergedInfo
+		for i := range merged {
+			if merged[i] == previous {
+				merged[i] = &mergedInfo
+			}
+		}
+	}
+
+	return merged
+}
+
+// For join two constraint expressions with ||
+func orConstraints(x string, y string) string {
+	if x == y {
+		return x
+	}
+
+	xExpr, xErr := constraint.Parse("//go:build " + x)
+	yExpr, yErr := constraint.Parse("//go:build " + y)
+	if xErr != nil || yErr != nil {
+		return ""
+	}
+
+	return (&constraint.OrExpr{X: xExpr, Y: yExpr}).String()
+}
+
+// A generated file with the sets it declares and the constraint it is built with
+type constrainedFile struct {
+	suffix     string
+	constraint string
+	setInfos   []*models.WireSetInfo
+}
+
+// For split the providers into the file of the sets that are always built and a file per set
+// whose providers are built only with some constraint, every file also gets the location constraint
+// A backend with a Build function needs every provider in one file, built when all of them are
+func splitConstrainedSets(setInfos []*models.WireSetInfo, selected *backend, locationConstraint string) []*constrainedFile {
+	setConstraints := make(map[string][]string)
+	for _, setInfo := range setInfos {
+		if setInfo.Constraint != "" {
+			setConstraints[setInfo.SetName] = append(setConstraints[setInfo.SetName], setInfo.Constraint)
+		}
+	}
+
+	if selected.build {
+		constraints := []string{locationConstraint}
+		for _, setInfo := range setInfos {
+			constraints = append(constraints, setInfo.Constraint)
+		}
+
+		return []*constrainedFile{{constraint: andConstraints(constraints...), setInfos: setInfos}}
+	}
+
+	files := []*constrainedFile{{constraint: locationConstraint, setInfos: make([]*models.WireSetInfo, 0, len(setInfos))}}
+	setFiles := make(map[string]*constrainedFile)
+	for _, setInfo := range setInfos {
+		constraints, constrained := setConstraints[setInfo.SetName]
+		if !constrained {
+			files[0].setInfos = append(files[0].setInfos, setInfo)
+			continue
+		}
+
+		setFile, exists := setFiles[setInfo.SetName]
+		if !exists {
+			setFile = &constrainedFile{
+				suffix:     setFileSuffix(setInfo.SetName),
+				constraint: andConstraints(append([]string{locationConstraint}, constraints...)...),
+			}
+			setFiles[setInfo.SetName] = setFile
+			files = append(files, setFile)
+		}
+		setFile.setInfos = append(setFile.setInfos, setInfo)
+	}
+
+	sort.SliceStable(files[1:], func(i, j int) bool {
+		return files[i+1].suffix < files[j+1].suffix
+	})
+
+	// A file without sets would only import the DI library
+	if len(files[0].setInfos) == 0 && len(files) > 1 {
+		return files[1:]
+	}
+
+	return files
+}
+
+// For get the part of the file name of a constrained set, it ends with _set so a set
+// named like a GOOS or test is not read as a file name constraint, e.g. wire_set_gen_cache_set.go
+func setFileSuffix(setName string) string {
+	suffix := snakeCase(setName)
+	if !strings.HasSuffix(suffix, "_set") {
+		suffix += "_set"
+	}
+
+	return "_" + suffix
+}
+
+// For add the suffix of a constrained set to a generated file name
+func setFileName(fileName string, suffix string) string {
+	return strings.TrimSuffix(fileName, ".go") + suffix + ".go"
+}
+
+// For split a generated file name into the file name of its location and the suffix of its set,
+// the suffix is empty for any other file, e.g. wire_set_gen_cache_set.go gives wire_set_gen.go
+func SplitSetFileName(fileName string, baseFileName string) (string, string) {
+	prefix := strings.TrimSuffix(baseFileName, ".go")
+	if !strings.HasPrefix(fileName, prefix+"_") || !strings.HasSuffix(fileName, "_set.go") {
+		return fileName, ""
+	}
+
+	return baseFileName, strings.TrimSuffix(strings.TrimPrefix(fileName, prefix), ".go")
+}
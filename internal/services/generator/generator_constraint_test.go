@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		filePath    string
+		fileContent string
+		expected    string
+	}{
+		{name: "Always built", filePath: "cache/cache.go", fileContent: "package cache\n", expected: ""},
+		{name: "Build line", filePath: "cache/cache.go", fileContent: "//go:build linux || darwin\n\npackage cache\n", expected: "linux || darwin"},
+		{name: "OS suffix", filePath: "cache/cache_windows.go", fileContent: "package cache\n", expected: "windows"},
+		{name: "OS and arch suffix", filePath: "cache/cache_linux_arm64.go", fileContent: "package cache\n", expected: "linux && arm64"},
+		{name: "Build line and suffix", filePath: "cache/cache_linux.go", fileContent: "//go:build cgo\n\npackage cache\n", expected: "cgo && linux"},
+		{name: "OS name alone", filePath: "cache/linux.go", fileContent: "package cache\n", expected: ""},
+		{name: "Build line after the package", filePath: "cache/cache.go", fileContent: "package cache\n\n//go:build linux\n", expected: ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, FileConstraint(tt.filePath, tt.fileContent))
+		})
+	}
+}
+
+func Test_injectorConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{name: "Wireinject", line: "//go:build wireinject", expected: ""},
+		{name: "Wireinject and an OS", line: "//go:build wireinject && linux", expected: "linux"},
+		{name: "Wireinject and a group", line: "//go:build (windows || darwin) && wireinject", expected: "windows || darwin"},
+		{name: "Negated tag", line: "//go:build wireinject && !js", expected: "!js"},
+		{name: "Not a constraint", line: "// wireinject", expected: ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, injectorConstraint(tt.line))
+		})
+	}
+}
+
+func Test_mergeConstrainedSetInfos(t *testing.T) {
+	t.Parallel()
+
+	config := &models.WireSetInfo{SetName: "Config", FunctionName: "NewConfig", ImportPath: "github.com/foo/bar/config"}
+	linux := &models.WireSetInfo{SetName: "Cache", FunctionName: "NewCache", ImportPath: "github.com/foo/bar/cache", Constraint: "linux"}
+	windows := &models.WireSetInfo{SetName: "Cache", FunctionName: "NewCache", ImportPath: "github.com/foo/bar/cache", Constraint: "windows"}
+
+	merged := mergeConstrainedSetInfos([]*models.WireSetInfo{linux, config, windows})
+
+	if assert.Len(t, merged, 2) {
+		assert.Equal(t, "NewCache", merged[0].FunctionName)
+		assert.Equal(t, "linux || windows", merged[0].Constraint)
+		assert.Equal(t, config, merged[1])
+	}
+	assert.Equal(t, "linux", linux.Constraint)
+}
+
+func Test_splitConstrainedSets(t *testing.T) {
+	t.Parallel()
+
+	config := &models.WireSetInfo{SetName: "Config", FunctionName: "NewConfig"}
+	cache := &models.WireSetInfo{SetName: "Cache", FunctionName: "NewCache", Constraint: "linux || windows"}
+	memory := &models.WireSetInfo{SetName: "Cache", FunctionName: "NewMemoryCache"}
+	tray := &models.WireSetInfo{SetName: "SystemTray", FunctionName: "NewTray", Constraint: "windows"}
+
+	testCases := []struct {
+		name               string
+		backend            string
+		setInfos           []*models.WireSetInfo
+		locationConstraint string
+		expected           []*constrainedFile
+	}{
+		{
+			name:     "Unconstrained",
+			backend:  BackendWire,
+			setInfos: []*models.WireSetInfo{config},
+			expected: []*constrainedFile{{setInfos: []*models.WireSetInfo{config}}},
+		},
+		{
+			name:               "File per constrained set",
+			backend:            BackendWire,
+			setInfos:           []*models.WireSetInfo{tray, config, cache, memory},
+			locationConstraint: "!js",
+			expected: []*constrainedFile{
+				{constraint: "!js", setInfos: []*models.WireSetInfo{config}},
+				{suffix: "_cache_set", constraint: "!js && (linux || windows)", setInfos: []*models.WireSetInfo{cache, memory}},
+				{suffix: "_system_tray_set", constraint: "!js && windows", setInfos: []*models.WireSetInfo{tray}},
+			},
+		},
+		{
+			name:     "Only constrained sets",
+			backend:  BackendWire,
+			setInfos: []*models.WireSetInfo{tray},
+			expected: []*constrainedFile{
+				{suffix: "_system_tray_set", constraint: "windows", setInfos: []*models.WireSetInfo{tray}},
+			},
+		},
+		{
+			name:     "Build function",
+			backend:  BackendPlain,
+			setInfos: []*models.WireSetInfo{config, cache, tray},
+			expected: []*constrainedFile{
+				{constraint: "(linux || windows) && windows", setInfos: []*models.WireSetInfo{config, cache, tray}},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, err := getBackend(tt.backend)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, splitConstrainedSets(tt.setInfos, selected, tt.locationConstraint))
+		})
+	}
+}
+
+func TestSplitSetFileName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		fileName         string
+		expectedFileName string
+		expectedSuffix   string
+	}{
+		{fileName: "wire_set_gen_cache_set.go", expectedFileName: "wire_set_gen.go", expectedSuffix: "_cache_set"},
+		{fileName: "wire_set_gen.go", expectedFileName: "wire_set_gen.go"},
+		{fileName: "wire_set_gen_test.go", expectedFileName: "wire_set_gen_test.go"},
+		{fileName: "wire_set_gen_linux.go", expectedFileName: "wire_set_gen_linux.go"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.fileName, func(t *testing.T) {
+			t.Parallel()
+
+			fileName, suffix := SplitSetFileName(tt.fileName, WireSetGenFileName)
+
+			assert.Equal(t, tt.expectedFileName, fileName)
+			assert.Equal(t, tt.expectedSuffix, suffix)
+		})
+	}
+
+	assert.Equal(t, "wire_set_gen_cache_set.go", setFileName(WireSetGenFileName, setFileSuffix("Cache")))
+	assert.True(t, IsGeneratedFileName("wire_set_gen_cache_set.dev.go"))
+}
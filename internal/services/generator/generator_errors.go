@@ -6,4 +6,27 @@ var (
 	ErrIsNotProjectRoot   = errors.New("is not in project root directory")
 	ErrInvalidGoModFile   = errors.New("invalid go.mod file")
 	ErrInvalidPackageName = errors.New("invalid package name")
+	ErrInvalidConfigFile  = errors.New("invalid config file")
+	ErrUnknownBackend     = errors.New("unknown backend")
+
+	ErrInvalidTemplate       = errors.New("invalid template")
+	ErrInvalidTemplateOutput = errors.New("invalid generated file")
+	ErrDeclaredName          = errors.New("generated name is already declared by the package")
+
+	ErrMissingSignature    = errors.New("provider signature is unknown")
+	ErrDuplicateProvider   = errors.New("type is provided more than once")
+	ErrDependencyCycle     = errors.New("providers depend on each other")
+	ErrUnsupportedProvider = errors.New("provider is not supported by the backend")
+	ErrUnimportablePackage = errors.New("provider package cannot be imported")
+	ErrInternalPackage     = errors.New("provider package is internal to another tree")
+
+	ErrMalformedAnnotation     = errors.New("malformed @WireSet annotation")
+	ErrUnknownAnnotationKey    = errors.New("unknown annotation key")
+	ErrMalformedDirective      = errors.New("malformed //wiresetgen directive")
+	ErrUnknownStyle            = errors.New("unknown annotation style")
+	ErrAnnotationNotOnFunction = errors.New("@WireSet annotation must be followed by a top-level function")
+	ErrInvalidSetName          = errors.New("invalid set name")
+	ErrDuplicateSetName        = errors.New("sets have the same variable name")
+	ErrInvalidProfile          = errors.New("invalid profile")
+	ErrInvalidConstraint       = errors.New("invalid build constraint")
 )
@@ -1,16 +1,22 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/graphzc/wiresetgen/internal/models"
 )
 
 // For get the module name from go.mod file
-func getModuleName(goModFile string) (string, error) {
+func GetModuleName(goModFile string) (string, error) {
 	lines := strings.Split(goModFile, "\n")
 	for _, line := range lines {
 		if strings.HasPrefix(line, "module") {
@@ -51,71 +57,206 @@ func extractPackageName(line string) (*string, error) {
 	return &packageParts[1], nil
 }
 
-// For indicates @WireSet("name") annotation and extracts the data
-func extractSetInfo(moduleName string, filePath string, fileContent string) []*models.WireSetInfo {
-	setInfos := make([]*models.WireSetInfo, 0)
+// For get the line of every top-level function of a file by function name
+func ExtractFunctions(fileContent string) map[string]int {
+	functions := make(map[string]int)
 
-	lines := strings.Split(fileContent, "\n")
-	packageName := ""
-	for i := range lines {
-		line := strings.TrimSpace(lines[i])
+	for i, line := range strings.Split(fileContent, "\n") {
+		if !strings.HasPrefix(line, "func ") {
+			continue
+		}
 
-		if strings.Contains(line, "package") {
-			packageParts := strings.Split(line, " ")
-			if len(packageParts) > 1 {
-				packageName = strings.TrimSpace(packageParts[1])
-			}
+		if functionName := extractFunctionName(line); functionName != "" {
+			functions[functionName] = i + 1
 		}
+	}
+
+	return functions
+}
+
+// For extract the name of a top-level function declaration line
+// Return empty string when the line is not a function or is a method
+func extractFunctionName(line string) string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "func ") {
+		return ""
+	}
 
-		if strings.Contains(line, "@WireSet(\"") {
-			setInfo := models.WireSetInfo{}
+	declaration := strings.TrimSpace(strings.TrimPrefix(line, "func"))
+	end := strings.IndexAny(declaration, "([")
+	if end <= 0 {
+		return ""
+	}
 
-			// Extract the annotation value
-			annotationParts := strings.Split(line, "\"")
-			if len(annotationParts) > 1 {
-				setInfo.SetName = strings.TrimSpace(annotationParts[1])
+	// Cut only the function name
+	functionName := strings.TrimSpace(declaration[:end])
+	if !token.IsIdentifier(functionName) {
+		return ""
+	}
+
+	return functionName
+}
+
+// For find the exported New* constructors without annotation in the files of one package
+// Suggestions are made only when every annotation of the package names the same set
+func SuggestAnnotations(packageFiles []*models.SourceFile) []*models.Suggestion {
+	setName := ""
+	suggestions := make([]*models.Suggestion, 0)
+
+	for _, packageFile := range packageFiles {
+		if !IsProviderFile(packageFile) {
+			continue
+		}
+
+		annotatedLines := make(map[int]bool)
+		for _, annotation := range ExtractAnnotations(packageFile.Content) {
+			annotatedLines[annotation.Line] = true
+			if annotation.Err != nil {
+				continue
 			}
 
-			// Extract the function name
-			// Go to the next line
-			if i+1 < len(lines) {
-				nextLine := lines[i+1]
-				functionParts := strings.Split(nextLine, " ")
+			if setName != "" && setName != annotation.SetName {
+				return nil
+			}
+			setName = annotation.SetName
+		}
 
-				var fullFunctionName string
-				if len(functionParts) > 1 {
-					fullFunctionName = strings.TrimSpace(functionParts[1])
-				}
+		for i, line := range strings.Split(packageFile.Content, "\n") {
+			// Only top-level declarations start at the beginning of the line
+			if !strings.HasPrefix(line, "func ") {
+				continue
+			}
 
-				// Cut only the function name
-				functionParts = strings.Split(fullFunctionName, "(")
-				if len(functionParts) > 0 {
-					setInfo.FunctionName = strings.TrimSpace(functionParts[0])
-				}
+			functionName := extractFunctionName(line)
+			if !isConstructorName(functionName) || annotatedLines[i] {
+				continue
 			}
 
-			// Set the package name
-			setInfo.PackageName = packageName
+			suggestions = append(suggestions, &models.Suggestion{
+				FilePath:     packageFile.FilePath,
+				Line:         i + 1,
+				FunctionName: functionName,
+			})
+		}
+	}
 
-			// Set the file path
-			// Cut the latest path separator section from the file path
-			pathParts := strings.Split(filePath, string(filepath.Separator))
+	if setName == "" {
+		return nil
+	}
 
-			if len(pathParts) > 0 {
-				pathParts = pathParts[:len(pathParts)-1]
-				// Convert back to import path format (always use forward slashes for Go imports)
-				cuttedFilePath := strings.Join(pathParts, "/")
+	for _, suggestion := range suggestions {
+		suggestion.SetName = setName
+	}
 
-				fullImportPath := path.Join(moduleName, cuttedFilePath)
+	return suggestions
+}
 
-				setInfo.ImportPath = strings.TrimSpace(fullImportPath)
-			}
+// For insert the suggested annotations above their functions in the style of the config
+func ApplySuggestions(fileContent string, suggestions []*models.Suggestion, style string) string {
+	annotations := make(map[int]string)
+	for _, suggestion := range suggestions {
+		annotations[suggestion.Line] = AnnotationComment(suggestion.SetName, style)
+	}
 
-			setInfos = append(setInfos, &setInfo)
+	lines := strings.Split(fileContent, "\n")
+	result := make([]string, 0, len(lines)+len(annotations))
+	for i, line := range lines {
+		if annotation, ok := annotations[i+1]; ok {
+			result = append(result, annotation)
 		}
+		result = append(result, line)
 	}
 
-	return setInfos
+	return strings.Join(result, "\n")
+}
+
+// For check if a file can hold providers, test, generated and injector files never do
+func IsProviderFile(sourceFile *models.SourceFile) bool {
+	fileName := filepath.Base(sourceFile.FilePath)
+	if strings.HasSuffix(fileName, "_test.go") || IsGeneratedFileName(fileName) {
+		return false
+	}
+
+	for _, line := range strings.Split(sourceFile.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if IsInjectorConstraint(line) {
+			return false
+		}
+		if strings.HasPrefix(line, "// Code generated ") && strings.HasSuffix(line, " DO NOT EDIT.") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A constructor is New or New followed by an upper case letter
+func isConstructorName(functionName string) bool {
+	if !strings.HasPrefix(functionName, "New") {
+		return false
+	}
+
+	rest := strings.TrimPrefix(functionName, "New")
+	return rest == "" || unicode.IsUpper([]rune(rest)[0])
+}
+
+// For indicates @WireSet("name") annotation and extracts the data
+// Malformed annotations are skipped with a warning, they are reported by the analyzer too, but a set name
+// that cannot be a Go identifier would break the generated file
+func extractSetInfo(moduleName string, filePath string, fileContent string) ([]*models.WireSetInfo, []string, error) {
+	setInfos := make([]*models.WireSetInfo, 0)
+	warnings := make([]string, 0)
+
+	// Find the package name
+	packageName := ""
+	for _, line := range strings.Split(fileContent, "\n") {
+		name, err := extractPackageName(line)
+		if err == nil && name != nil {
+			packageName = *name
+			break
+		}
+	}
+
+	// Set the file path
+	// Cut the latest path separator section from the file path
+	pathParts := strings.Split(filePath, string(filepath.Separator))
+	pathParts = pathParts[:len(pathParts)-1]
+	// Convert back to import path format (always use forward slashes for Go imports)
+	cuttedFilePath := strings.Join(pathParts, "/")
+	importPath := strings.TrimSpace(path.Join(moduleName, cuttedFilePath))
+
+	fileConstraint := FileConstraint(filePath, fileContent)
+	for _, annotation := range ExtractAnnotations(fileContent) {
+		if errors.Is(annotation.Err, ErrInvalidSetName) || errors.Is(annotation.Err, ErrInvalidProfile) {
+			return nil, nil, fmt.Errorf("%s:%d:%d: %w", filePath, annotation.Line, annotation.Column, annotation.Err)
+		}
+
+		for _, warning := range annotation.Warnings {
+			warnings = append(warnings, fmt.Sprintf("%s:%d:%d: %v", filePath, annotation.Line, AnnotationErrorColumn(warning, annotation.Column), warning))
+		}
+		if errors.Is(annotation.Err, ErrMalformedAnnotation) || errors.Is(annotation.Err, ErrMalformedDirective) {
+			warnings = append(warnings, fmt.Sprintf("%s:%d:%d: %v, the annotation is ignored", filePath, annotation.Line,
+				AnnotationErrorColumn(annotation.Err, annotation.Column), annotation.Err))
+		}
+		if annotation.Err != nil {
+			continue
+		}
+
+		setInfos = append(setInfos, &models.WireSetInfo{
+			PackageName:  packageName,
+			SetName:      annotation.SetName,
+			FunctionName: annotation.FunctionName,
+			ImportPath:   importPath,
+			FilePath:     filePath,
+			Name:         annotation.Name,
+			Bind:         annotation.Bind,
+			Profiles:     annotation.Profiles,
+			Constraint:   fileConstraint,
+			Line:         annotation.Line + 1,
+		})
+	}
+
+	return setInfos, warnings, nil
 }
 
 // For extract the wiregen location from the file
@@ -126,12 +267,14 @@ func extractWireGenLocation(filePath string, fileContent string) (*models.WireGe
 	lines := strings.Split(fileContent, "\n")
 
 	isFound := false
+	constraint := ""
 
 	for i := range lines {
 		line := strings.TrimSpace(lines[i])
 
-		if strings.HasPrefix(line, "//go:build wireinject") {
+		if IsInjectorConstraint(line) {
 			isFound = true
+			constraint = injectorConstraint(line)
 			continue
 		}
 
@@ -142,9 +285,23 @@ func extractWireGenLocation(filePath string, fileContent string) (*models.WireGe
 			}
 
 			if packageName != nil {
+				profiles, err := ExtractProfileDirective(lines)
+				if err != nil {
+					return nil, fmt.Errorf("%s:%w", filePath, err)
+				}
+
+				backend, err := extractBackendDirective(lines)
+				if err != nil {
+					return nil, fmt.Errorf("%s:%w", filePath, err)
+				}
+
 				return &models.WireGenLocation{
 					PackageName:   *packageName,
 					DirectoryPath: filepath.Dir(filePath),
+					FilePath:      filePath,
+					Backend:       backend,
+					Profiles:      profiles,
+					Constraint:    constraint,
 				}, nil
 			}
 		}
@@ -156,3 +313,282 @@ func extractWireGenLocation(filePath string, fileContent string) (*models.WireGe
 
 	return nil, nil
 }
+
+// For get the backend of a //wiresetgen:backend directive, empty when the file has none
+func extractBackendDirective(lines []string) (string, error) {
+	found, err := extractDirective(lines, BackendDirective)
+	if err != nil || found == nil {
+		return "", err
+	}
+
+	backends, err := found.values()
+	if err != nil {
+		return "", err
+	}
+	if len(backends) != 1 {
+		return "", found.errorAt(found.column, fmt.Errorf("%w: %s takes one backend", ErrMalformedDirective, BackendDirective))
+	}
+
+	return backends[0], nil
+}
+
+// Predeclared type names never get qualified with an import path
+var predeclaredTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true,
+	"complex64": true, "complex128": true, "error": true,
+	"float32": true, "float64": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"rune": true, "string": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// For fill the parameter and result types of the annotated functions
+// Types are written with the full import path, e.g. *github.com/foo/bar.Service
+func extractFuncSignatures(filePath string, fileContent string, setInfos []*models.WireSetInfo) error {
+	if len(setInfos) == 0 {
+		return nil
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, fileContent, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	// Create a map[name]importPath for the file imports
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := DefaultImportName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = importPath
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil {
+			continue
+		}
+
+		for _, setInfo := range setInfos {
+			if setInfo.FunctionName != funcDecl.Name.Name {
+				continue
+			}
+
+			typeParams := make(map[string]bool)
+			for _, field := range fieldList(funcDecl.Type.TypeParams) {
+				for _, name := range field.Names {
+					typeParams[name.Name] = true
+				}
+			}
+
+			qualify := func(expr ast.Expr) string {
+				return qualifiedTypeString(expr, setInfo.ImportPath, imports, typeParams)
+			}
+
+			setInfo.ParamTypes = fieldTypes(funcDecl.Type.Params, qualify)
+			setInfo.ResultTypes = fieldTypes(funcDecl.Type.Results, qualify)
+			setInfo.Doc = docText(funcDecl.Doc)
+
+			// The bound interface is written like the types of the file, e.g. io.Reader
+			if setInfo.Bind != "" {
+				if expr, err := parser.ParseExpr(setInfo.Bind); err == nil {
+					setInfo.Bind = qualify(expr)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// For get the doc comment text without the annotation lines
+func docText(doc *ast.CommentGroup) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if !strings.Contains(line, AnnotationMarker) {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// For guess the package name of an import path without loading it, like goimports does
+// e.g. github.com/foo/bar/v2 is bar, github.com/go-redis/redis is redis and gopkg.in/yaml.v3 is yaml
+func DefaultImportName(importPath string) string {
+	name := ImportPathBase(importPath)
+
+	// The go- prefix is a repository convention, the name ends at the first character a package name cannot have
+	name = strings.TrimPrefix(name, "go-")
+	if end := strings.IndexFunc(name, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}); end >= 0 {
+		name = name[:end]
+	}
+
+	return name
+}
+
+// For get the last element of an import path without the major version suffix, e.g. github.com/foo/bar/v2 is bar
+func ImportPathBase(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = parts[len(parts)-2]
+		}
+	}
+
+	return name
+}
+
+func fieldList(fields *ast.FieldList) []*ast.Field {
+	if fields == nil {
+		return nil
+	}
+
+	return fields.List
+}
+
+// For convert the field list to one type per value, `a, b int` becomes [int, int]
+func fieldTypes(fields *ast.FieldList, qualify func(ast.Expr) string) []string {
+	types := make([]string, 0)
+	for _, field := range fieldList(fields) {
+		typeName := qualify(field.Type)
+
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+
+		for range count {
+			types = append(types, typeName)
+		}
+	}
+
+	return types
+}
+
+// For print the type expression with every named type qualified by its import path
+func qualifiedTypeString(expr ast.Expr, importPath string, imports map[string]string, typeParams map[string]bool) string {
+	qualify := func(expr ast.Expr) string {
+		return qualifiedTypeString(expr, importPath, imports, typeParams)
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if predeclaredTypes[t.Name] || typeParams[t.Name] {
+			return t.Name
+		}
+
+		return importPath + "." + t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			if pkgPath, exists := imports[pkg.Name]; exists {
+				return pkgPath + "." + t.Sel.Name
+			}
+
+			// A bound interface may name a package the file does not import, e.g. io.Reader
+			return pkg.Name + "." + t.Sel.Name
+		}
+
+		return qualify(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + qualify(t.X)
+	case *ast.ParenExpr:
+		return qualify(t.X)
+	case *ast.Ellipsis:
+		return "..." + qualify(t.Elt)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + qualify(t.Elt)
+		}
+
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			return "[" + lit.Value + "]" + qualify(t.Elt)
+		}
+
+		return "[...]" + qualify(t.Elt)
+	case *ast.MapType:
+		return "map[" + qualify(t.Key) + "]" + qualify(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + qualify(t.Value)
+		case ast.RECV:
+			return "<-chan " + qualify(t.Value)
+		default:
+			return "chan " + qualify(t.Value)
+		}
+	case *ast.FuncType:
+		params := strings.Join(fieldTypes(t.Params, qualify), ", ")
+		results := fieldTypes(t.Results, qualify)
+
+		switch len(results) {
+		case 0:
+			return "func(" + params + ")"
+		case 1:
+			return "func(" + params + ") " + results[0]
+		default:
+			return "func(" + params + ") (" + strings.Join(results, ", ") + ")"
+		}
+	case *ast.InterfaceType:
+		if len(fieldList(t.Methods)) == 0 {
+			return "interface{}"
+		}
+
+		return "interface{...}"
+	case *ast.StructType:
+		if len(fieldList(t.Fields)) == 0 {
+			return "struct{}"
+		}
+
+		return "struct{...}"
+	case *ast.IndexExpr:
+		return qualify(t.X) + "[" + qualify(t.Index) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, 0, len(t.Indices))
+		for _, index := range t.Indices {
+			indices = append(indices, qualify(index))
+		}
+
+		return qualify(t.X) + "[" + strings.Join(indices, ", ") + "]"
+	}
+
+	return fmt.Sprintf("%T", expr)
+}
+
+// For get the names declared at the top level of a file, methods are not
+func TopLevelNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names[name.Name] = true
+					}
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	return names
+}
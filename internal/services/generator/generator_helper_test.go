@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_getModuleName(t *testing.T) {
+func TestGetModuleName(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -42,7 +42,7 @@ func Test_getModuleName(t *testing.T) {
 		t.Run(tc.name, func(tt *testing.T) {
 			tt.Parallel()
 
-			moduleName, err := getModuleName(tc.goModFile)
+			moduleName, err := GetModuleName(tc.goModFile)
 
 			assert.Equal(tt, tc.expectedName, moduleName)
 			assert.ErrorIs(tt, err, tc.expectedErr)
@@ -165,6 +165,7 @@ func Test_extractWireGenLocation(t *testing.T) {
 			expectedInfo: &models.WireGenLocation{
 				PackageName:   "wire",
 				DirectoryPath: "internal/wire",
+				FilePath:      "internal/wire/wire.go",
 			},
 			expectedError: nil,
 		},
@@ -182,9 +183,73 @@ func Test_extractWireGenLocation(t *testing.T) {
 			expectedInfo: &models.WireGenLocation{
 				PackageName:   "wire",
 				DirectoryPath: "internal/wire",
+				FilePath:      "internal/wire/wire.go",
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "Backend directive",
+			filePath: "internal/wire/wire.go",
+			fileContent: `
+			//go:build wireinject
+
+			package wire
+
+			//wiresetgen:backend dig
+			`,
+			expectedInfo: &models.WireGenLocation{
+				PackageName:   "wire",
+				DirectoryPath: "internal/wire",
+				FilePath:      "internal/wire/wire.go",
+				Backend:       "dig",
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Profile directive",
+			filePath: "cmd/api/wire.go",
+			fileContent: `
+			//go:build wireinject
+
+			package main
+
+			//wiresetgen:profile dev prod
+			`,
+			expectedInfo: &models.WireGenLocation{
+				PackageName:   "main",
+				DirectoryPath: "cmd/api",
+				FilePath:      "cmd/api/wire.go",
+				Profiles:      []string{"dev", "prod"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Platform injector",
+			filePath: "cmd/desktop/wire.go",
+			fileContent: `
+			//go:build wireinject && (windows || darwin)
+
+			package main
+			`,
+			expectedInfo: &models.WireGenLocation{
+				PackageName:   "main",
+				DirectoryPath: "cmd/desktop",
+				FilePath:      "cmd/desktop/wire.go",
+				Constraint:    "windows || darwin",
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Generated profile file",
+			filePath: "cmd/api/wire_set_gen.dev.go",
+			fileContent: `
+			//go:build wireinject_dev
+
+			package main
+			`,
+			expectedInfo:  nil,
+			expectedError: nil,
+		},
 		{
 			name:          "No wireinject in file",
 			filePath:      "internal/wire/not_wire_file.go",
@@ -216,3 +281,391 @@ func Test_extractWireGenLocation(t *testing.T) {
 		})
 	}
 }
+
+func Test_extractFuncSignatures_bind(t *testing.T) {
+	t.Parallel()
+
+	fileContent := `package service
+
+import (
+	"io"
+
+	repo "github.com/foo/bar/internal/repositories/user"
+)
+
+func NewReader() *File { return nil }
+
+func NewRepo() *repo.Repository { return nil }
+`
+	setInfos := []*models.WireSetInfo{
+		{FunctionName: "NewReader", ImportPath: "github.com/foo/bar/service", Bind: "io.Reader"},
+		{FunctionName: "NewRepo", ImportPath: "github.com/foo/bar/service", Bind: "Repository"},
+	}
+
+	err := extractFuncSignatures("service/service.go", fileContent, setInfos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "io.Reader", setInfos[0].Bind)
+	assert.Equal(t, "github.com/foo/bar/service.Repository", setInfos[1].Bind)
+}
+
+func Test_extractFuncSignatures(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		fileContent     string
+		functionName    string
+		expectedParams  []string
+		expectedResults []string
+		expectedDoc     string
+		expectedError   bool
+	}{
+		{
+			name: "Local and imported types",
+			fileContent: `package service
+
+import (
+	"context"
+
+	repo "github.com/foo/bar/internal/repositories/user"
+)
+
+func NewService(ctx context.Context, r repo.Repository, cfg *Config) (*Service, func(), error) {
+	return nil, nil, nil
+}
+`,
+			functionName:    "NewService",
+			expectedParams:  []string{"context.Context", "github.com/foo/bar/internal/repositories/user.Repository", "*github.com/foo/bar/internal/services/service.Config"},
+			expectedResults: []string{"*github.com/foo/bar/internal/services/service.Service", "func()", "error"},
+		},
+		{
+			name: "Doc comment without the annotation",
+			fileContent: `package service
+
+// NewService builds the service.
+//
+// @WireSet("Service")
+func NewService() *Service {
+	return nil
+}
+`,
+			functionName:    "NewService",
+			expectedParams:  []string{},
+			expectedResults: []string{"*github.com/foo/bar/internal/services/service.Service"},
+			expectedDoc:     "NewService builds the service.",
+		},
+		{
+			name: "Grouped parameters and composite types",
+			fileContent: `package service
+
+import "github.com/foo/bar/v2"
+
+func NewService(a, b string, m map[string][]*bar.Item, opts ...Option) Service {
+	return Service{}
+}
+`,
+			functionName:    "NewService",
+			expectedParams:  []string{"string", "string", "map[string][]*github.com/foo/bar/v2.Item", "...github.com/foo/bar/internal/services/service.Option"},
+			expectedResults: []string{"github.com/foo/bar/internal/services/service.Service"},
+		},
+		{
+			name: "Generic type parameters are not qualified",
+			fileContent: `package service
+
+func NewService[T any](value T) *Box[T] {
+	return nil
+}
+`,
+			functionName:    "NewService",
+			expectedParams:  []string{"T"},
+			expectedResults: []string{"*github.com/foo/bar/internal/services/service.Box[T]"},
+		},
+		{
+			name: "Methods are ignored",
+			fileContent: `package service
+
+func (s *Service) NewService() int {
+	return 0
+}
+`,
+			functionName:    "NewService",
+			expectedParams:  nil,
+			expectedResults: nil,
+		},
+		{
+			name:          "Invalid file",
+			fileContent:   "package service\n\nfunc NewService( {",
+			functionName:  "NewService",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			setInfo := &models.WireSetInfo{
+				FunctionName: tc.functionName,
+				ImportPath:   "github.com/foo/bar/internal/services/service",
+			}
+
+			err := extractFuncSignatures("internal/services/service/service.go", tc.fileContent, []*models.WireSetInfo{setInfo})
+
+			if tc.expectedError {
+				assert.Error(tt, err)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.expectedParams, setInfo.ParamTypes)
+			assert.Equal(tt, tc.expectedResults, setInfo.ResultTypes)
+			assert.Equal(tt, tc.expectedDoc, setInfo.Doc)
+		})
+	}
+}
+
+func TestExtractAnnotations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		fileContent         string
+		expectedAnnotations []*models.Annotation
+	}{
+		{
+			name:        "Valid annotation",
+			fileContent: "package user\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 3, Column: 4, SetName: "Service", FunctionName: "NewService"},
+			},
+		},
+		{
+			name:        "Generic function",
+			fileContent: "//@WireSet(\"Repo\")\nfunc NewRepo[T any]() *Repo[T] {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 3, SetName: "Repo", FunctionName: "NewRepo"},
+			},
+		},
+		{
+			name:        "Name and bind",
+			fileContent: "// @WireSet(\"Service\", name=\"primary\", bind=\"io.Reader\")\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Service", FunctionName: "NewService", Name: "primary", Bind: "io.Reader"},
+			},
+		},
+		{
+			name:        "Profiles",
+			fileContent: "// @WireSet(\"Cache\", profile=\"dev, test\")\nfunc NewCache() *Cache {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Cache", FunctionName: "NewCache", Profiles: []string{"dev", "test"}},
+			},
+		},
+		{
+			name:        "Profile is not a build tag",
+			fileContent: "// @WireSet(\"Cache\", profile=\"dev-local\")\nfunc NewCache() *Cache {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Cache", Err: ValidateProfile("dev-local")},
+			},
+		},
+		{
+			name:        "Set key and trailing comma",
+			fileContent: "// @WireSet(bind=`io.Reader`, set=\"Service\",)\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Service", FunctionName: "NewService", Bind: "io.Reader"},
+			},
+		},
+		{
+			name:        "Unknown argument",
+			fileContent: "// @WireSet(\"Service\", scope=\"app\")\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{
+					Line: 1, Column: 4, SetName: "Service", FunctionName: "NewService",
+					Warnings: []error{annotationErrorf(24, ErrUnknownAnnotationKey, "scope is ignored, the keys are bind, name, profile and set")},
+				},
+			},
+		},
+		{
+			name:        "Unquoted argument",
+			fileContent: "// @WireSet(\"Service\", bind=io.Reader)\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, Err: annotationErrorf(29, ErrMalformedAnnotation, "bind=io.Reader must be a quoted string")},
+			},
+		},
+		{
+			name:        "Missing quotes",
+			fileContent: "// @WireSet(Service)\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, Err: annotationErrorf(13, ErrMalformedAnnotation, "Service must be a quoted string")},
+			},
+		},
+		{
+			name:        "Empty name",
+			fileContent: "// @WireSet(\"\")\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, Err: annotationErrorf(13, ErrMalformedAnnotation, "the set name is empty")},
+			},
+		},
+		{
+			name:        "Set name is not an identifier",
+			fileContent: "// @WireSet(\"my-set\")\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "my-set", Err: ValidateSetName("my-set", 14)},
+			},
+		},
+		{
+			name:        "Not followed by a function",
+			fileContent: "// @WireSet(\"Service\")\ntype Service struct{}\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Service", Err: ErrAnnotationNotOnFunction},
+			},
+		},
+		{
+			name:        "Method",
+			fileContent: "// @WireSet(\"Service\")\nfunc (s *Service) New() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 4, SetName: "Service", Err: ErrAnnotationNotOnFunction},
+			},
+		},
+		{
+			name:        "Directive",
+			fileContent: "// NewService creates the service\n\t//wiresetgen:set Service bind=io.Reader profile=\"dev,test\"\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{
+					Line: 2, Column: 2, SetName: "Service", FunctionName: "NewService", Directive: true,
+					Bind: "io.Reader", Profiles: []string{"dev", "test"},
+				},
+			},
+		},
+		{
+			name:        "Directive set name is not an identifier",
+			fileContent: "//wiresetgen:set my-set\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 1, SetName: "my-set", Directive: true, Err: ValidateSetName("my-set", 18)},
+			},
+		},
+		{
+			name:        "Directive without a set name",
+			fileContent: "//wiresetgen:set name=primary\nfunc NewService() *Service {\n",
+			expectedAnnotations: []*models.Annotation{
+				{Line: 1, Column: 1, Directive: true, Name: "primary", Err: annotationErrorf(1, ErrMalformedDirective, "the set name is missing")},
+			},
+		},
+		{
+			name:                "Directive after a space",
+			fileContent:         "// wiresetgen:set Service\nfunc NewService() *Service {\n//wiresetgen:settings\n",
+			expectedAnnotations: []*models.Annotation{},
+		},
+		{
+			name:                "Marker outside of a comment",
+			fileContent:         "var marker = \"@WireSet(\\\"Service\\\")\" // marker\n",
+			expectedAnnotations: []*models.Annotation{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			annotations := ExtractAnnotations(tc.fileContent)
+
+			assert.Equal(tt, tc.expectedAnnotations, annotations)
+		})
+	}
+}
+
+func TestSuggestAnnotations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		packageFiles        []*models.SourceFile
+		expectedSuggestions []*models.Suggestion
+	}{
+		{
+			name: "Constructor without annotation",
+			packageFiles: []*models.SourceFile{
+				{FilePath: "user/service.go", Content: "package user\n\n// @WireSet(\"User\")\nfunc NewService() *Service {\n}\n"},
+				{FilePath: "user/repo.go", Content: "package user\n\n// NewRepo creates the repository\nfunc NewRepo() *Repo {\n}\n\nfunc Newline() {}\nfunc newCache() {}\n"},
+			},
+			expectedSuggestions: []*models.Suggestion{
+				{FilePath: "user/repo.go", Line: 4, FunctionName: "NewRepo", SetName: "User"},
+			},
+		},
+		{
+			name: "Package with several sets",
+			packageFiles: []*models.SourceFile{
+				{FilePath: "user/service.go", Content: "// @WireSet(\"User\")\nfunc NewService() *Service {\n}\n\n// @WireSet(\"Admin\")\nfunc NewAdmin() *Admin {\n}\n\nfunc NewRepo() *Repo {\n}\n"},
+			},
+			expectedSuggestions: nil,
+		},
+		{
+			name: "Package without annotation",
+			packageFiles: []*models.SourceFile{
+				{FilePath: "user/service.go", Content: "func NewService() *Service {\n}\n"},
+			},
+			expectedSuggestions: nil,
+		},
+		{
+			name: "Malformed annotation, test and generated files",
+			packageFiles: []*models.SourceFile{
+				{FilePath: "user/service.go", Content: "// @WireSet(\"User\")\nfunc NewService() *Service {\n}\n\n// @WireSet(Repo)\nfunc NewRepo() *Repo {\n}\n"},
+				{FilePath: "user/service_test.go", Content: "func NewFake() *Service {\n}\n"},
+				{FilePath: "user/mock.go", Content: "// Code generated by mockery. DO NOT EDIT.\n\nfunc NewMock() *Mock {\n}\n"},
+			},
+			expectedSuggestions: []*models.Suggestion{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			suggestions := SuggestAnnotations(tc.packageFiles)
+
+			assert.Equal(tt, tc.expectedSuggestions, suggestions)
+		})
+	}
+}
+
+func TestApplySuggestions(t *testing.T) {
+	t.Parallel()
+
+	content := "package user\n\n// NewRepo creates the repository\nfunc NewRepo() *Repo {\n}\n\nfunc NewCache() *Cache {\n}\n"
+	suggestions := []*models.Suggestion{
+		{FilePath: "user/repo.go", Line: 4, FunctionName: "NewRepo", SetName: "User"},
+		{FilePath: "user/repo.go", Line: 7, FunctionName: "NewCache", SetName: "User"},
+	}
+
+	result := ApplySuggestions(content, suggestions, "")
+
+	assert.Equal(t, "package user\n\n// NewRepo creates the repository\n// @WireSet(\"User\")\nfunc NewRepo() *Repo {\n}\n\n// @WireSet(\"User\")\nfunc NewCache() *Cache {\n}\n", result)
+
+	result = ApplySuggestions(content, suggestions, StyleDirective)
+
+	assert.Equal(t, "package user\n\n// NewRepo creates the repository\n//wiresetgen:set User\nfunc NewRepo() *Repo {\n}\n\n//wiresetgen:set User\nfunc NewCache() *Cache {\n}\n", result)
+}
+
+func TestDefaultImportName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		importPath   string
+		expectedName string
+	}{
+		{importPath: "context", expectedName: "context"},
+		{importPath: "github.com/foo/bar/v2", expectedName: "bar"},
+		{importPath: "github.com/go-redis/redis", expectedName: "redis"},
+		{importPath: "github.com/foo/go-redis", expectedName: "redis"},
+		{importPath: "gopkg.in/yaml.v3", expectedName: "yaml"},
+		{importPath: "github.com/foo/bar-baz", expectedName: "bar"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.importPath, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expectedName, DefaultImportName(tc.importPath))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+const (
+	NamingSuffix = "suffix"
+	NamingPrefix = "prefix"
+	NamingExact  = "exact"
+
+	NamingExported   = "exported"
+	NamingUnexported = "unexported"
+
+	DefaultSetAffix = "Set"
+)
+
+// For get the variable name of the generated set, a nil naming appends Set
+// The affix is not added twice, e.g. ServiceSet stays ServiceSet
+func SetVarName(setName string, naming *models.NamingConfig) string {
+	strategy, affix, export := namingOptions(naming)
+
+	varName := setName
+	switch strategy {
+	case NamingPrefix:
+		if !hasAffixPrefix(setName, affix) {
+			varName = affix + upperFirst(setName)
+		}
+	case NamingSuffix:
+		if !strings.HasSuffix(setName, affix) {
+			varName = setName + affix
+		}
+	}
+
+	switch export {
+	case NamingExported:
+		varName = upperFirst(varName)
+	case NamingUnexported:
+		varName = lowerInitialism(varName)
+	}
+
+	return varName
+}
+
+// For get the set name of a generated variable name, the inverse of SetVarName without the case change
+func SetNameFromVarName(varName string, naming *models.NamingConfig) string {
+	strategy, affix, _ := namingOptions(naming)
+
+	setName := varName
+	switch strategy {
+	case NamingPrefix:
+		if hasAffixPrefix(varName, affix) {
+			setName = strings.TrimPrefix(varName, affix)
+		}
+	case NamingSuffix:
+		setName = strings.TrimSuffix(varName, affix)
+	}
+
+	if setName == "" {
+		return varName
+	}
+
+	return setName
+}
+
+// For fill the defaults of the naming, the strategy is suffix and the affix is Set
+func namingOptions(naming *models.NamingConfig) (string, string, string) {
+	if naming == nil {
+		return NamingSuffix, DefaultSetAffix, ""
+	}
+
+	strategy, affix := naming.Strategy, naming.Affix
+	if strategy == "" {
+		strategy = NamingSuffix
+	}
+	if affix == "" {
+		affix = DefaultSetAffix
+	}
+
+	return strategy, affix, naming.Export
+}
+
+// A prefix counts only when a new word follows it, e.g. Setting does not start with Set
+func hasAffixPrefix(name string, affix string) bool {
+	rest := strings.TrimPrefix(name, affix)
+	return len(rest) < len(name) && rest != "" && !unicode.IsLower([]rune(rest)[0])
+}
+
+// For get the naming of a backend, the affix of the config wins over the backend affix
+func backendNaming(naming *models.NamingConfig, selected *backend) *models.NamingConfig {
+	resolved := &models.NamingConfig{Affix: selected.affix}
+	if naming != nil {
+		resolved.Strategy = naming.Strategy
+		resolved.Export = naming.Export
+		if naming.Affix != "" {
+			resolved.Affix = naming.Affix
+		}
+	}
+
+	return resolved
+}
+
+// For check the naming of the config
+func validateNaming(naming *models.NamingConfig) error {
+	switch naming.Strategy {
+	case "", NamingSuffix, NamingPrefix, NamingExact:
+	default:
+		return fmt.Errorf("naming strategy %q, expected %s, %s or %s", naming.Strategy, NamingSuffix, NamingPrefix, NamingExact)
+	}
+
+	switch naming.Export {
+	case "", NamingExported, NamingUnexported:
+	default:
+		return fmt.Errorf("naming export %q, expected %s or %s", naming.Export, NamingExported, NamingUnexported)
+	}
+
+	if naming.Affix != "" && !token.IsIdentifier(naming.Affix) {
+		return fmt.Errorf("naming affix %q is not a Go identifier", naming.Affix)
+	}
+
+	return nil
+}
+
+// For check that a set name is a Go identifier, column is the column of its first character
+// The error names the first character that is not allowed
+func ValidateSetName(setName string, column int) error {
+	if token.IsKeyword(setName) {
+		return fmt.Errorf("%w: %q is a Go keyword", ErrInvalidSetName, setName)
+	}
+
+	for offset, r := range setName {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+
+		if unicode.IsDigit(r) {
+			if offset > 0 {
+				continue
+			}
+
+			return fmt.Errorf("%w: %q starts with the digit %q at column %d", ErrInvalidSetName, setName, r, column)
+		}
+
+		return fmt.Errorf("%w: %q has %q at column %d, a Go identifier has only letters, digits and underscores",
+			ErrInvalidSetName, setName, r, column+offset)
+	}
+
+	return nil
+}
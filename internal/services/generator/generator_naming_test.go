@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetVarName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		setName         string
+		naming          *models.NamingConfig
+		expectedVarName string
+		expectedSetName string
+	}{
+		{
+			name:            "Default",
+			setName:         "Service",
+			expectedVarName: "ServiceSet",
+			expectedSetName: "Service",
+		},
+		{
+			name:            "Suffix is not added twice",
+			setName:         "ServiceSet",
+			expectedVarName: "ServiceSet",
+			expectedSetName: "Service",
+		},
+		{
+			name:            "Prefix",
+			setName:         "service",
+			naming:          &models.NamingConfig{Strategy: NamingPrefix, Affix: "Provide"},
+			expectedVarName: "ProvideService",
+			expectedSetName: "Service",
+		},
+		{
+			name:            "Prefix needs a new word",
+			setName:         "Settings",
+			naming:          &models.NamingConfig{Strategy: NamingPrefix},
+			expectedVarName: "SetSettings",
+			expectedSetName: "Settings",
+		},
+		{
+			name:            "Exact",
+			setName:         "Providers",
+			naming:          &models.NamingConfig{Strategy: NamingExact},
+			expectedVarName: "Providers",
+			expectedSetName: "Providers",
+		},
+		{
+			name:            "Unexported",
+			setName:         "HTTPService",
+			naming:          &models.NamingConfig{Export: NamingUnexported},
+			expectedVarName: "httpServiceSet",
+			expectedSetName: "httpService",
+		},
+		{
+			name:            "Exported",
+			setName:         "service",
+			naming:          &models.NamingConfig{Export: NamingExported, Affix: "Providers"},
+			expectedVarName: "ServiceProviders",
+			expectedSetName: "Service",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			varName := SetVarName(tc.setName, tc.naming)
+
+			assert.Equal(tt, tc.expectedVarName, varName)
+			assert.Equal(tt, tc.expectedSetName, SetNameFromVarName(varName, tc.naming))
+		})
+	}
+}
+
+func TestValidateSetName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		setName       string
+		expectedError string
+	}{
+		{setName: "Service"},
+		{setName: "user_repo2"},
+		{setName: "my-set", expectedError: `invalid set name: "my-set" has '-' at column 14, a Go identifier has only letters, digits and underscores`},
+		{setName: "2fa", expectedError: `invalid set name: "2fa" starts with the digit '2' at column 12`},
+		{setName: "type", expectedError: `invalid set name: "type" is a Go keyword`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.setName, func(tt *testing.T) {
+			tt.Parallel()
+
+			err := ValidateSetName(tc.setName, 12)
+
+			if tc.expectedError == "" {
+				assert.NoError(tt, err)
+				return
+			}
+
+			assert.ErrorIs(tt, err, ErrInvalidSetName)
+			assert.EqualError(tt, err, tc.expectedError)
+		})
+	}
+}
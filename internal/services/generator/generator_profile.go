@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+const (
+	ProfileDirective = "//wiresetgen:profile"
+
+	// A location with several profiles gets a file per profile built with this tag and the profile name
+	ProfileTagPrefix = "wireinject_"
+)
+
+// A profile becomes a build tag and a part of the file name
+var profilePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]*$`)
+
+// For check that a profile can be used in a build tag and a file name
+func ValidateProfile(profile string) error {
+	if !profilePattern.MatchString(profile) {
+		return fmt.Errorf("%w: %q, use letters, digits and underscores", ErrInvalidProfile, profile)
+	}
+
+	return nil
+}
+
+// For split a comma separated list of profiles, e.g. the dev,test value of an annotation
+func parseProfiles(value string) ([]string, error) {
+	profiles := make([]string, 0)
+	for _, profile := range strings.Split(value, ",") {
+		profile = strings.TrimSpace(profile)
+		if err := ValidateProfile(profile); err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// For get the profiles of a //wiresetgen:profile directive, nil when the file has none
+// The error has the line and the column of the directive argument
+func ExtractProfileDirective(lines []string) ([]string, error) {
+	found, err := extractDirective(lines, ProfileDirective)
+	if err != nil || found == nil {
+		return nil, err
+	}
+
+	profiles, err := found.values()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, profile := range profiles {
+		if err := ValidateProfile(profile); err != nil {
+			return nil, found.errorAt(found.arguments[i].column, err)
+		}
+	}
+
+	return profiles, nil
+}
+
+// For pick the profiles of a location, the directive of the injector file wins over the config of its directory
+// A location without profiles gets a single file with the providers that have no profile
+func locationProfiles(wireGenLocation *models.WireGenLocation, config *models.Config) []string {
+	if len(wireGenLocation.Profiles) > 0 {
+		return wireGenLocation.Profiles
+	}
+
+	if profiles := config.Profiles[filepath.ToSlash(wireGenLocation.DirectoryPath)]; len(profiles) > 0 {
+		return profiles
+	}
+
+	return []string{""}
+}
+
+// For keep the providers of a profile, a provider without a profile is in every profile
+func profileSetInfos(setInfos []*models.WireSetInfo, profile string) []*models.WireSetInfo {
+	filtered := make([]*models.WireSetInfo, 0, len(setInfos))
+	for _, setInfo := range setInfos {
+		if len(setInfo.Profiles) == 0 {
+			filtered = append(filtered, setInfo)
+			continue
+		}
+
+		for _, setProfile := range setInfo.Profiles {
+			if setProfile == profile {
+				filtered = append(filtered, setInfo)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// For name the file of a profile, the profile goes after a dot so a test or linux profile
+// is not read as a test file or a GOOS constraint, e.g. wire_set_gen.test.go
+func profileFileName(fileName string, profile string) string {
+	return strings.TrimSuffix(fileName, ".go") + "." + profile + ".go"
+}
+
+// For split the file of a profile into the file name without the profile and the profile,
+// the profile is empty for any other file
+func SplitProfileFileName(fileName string) (string, string) {
+	base, profile, found := strings.Cut(strings.TrimSuffix(fileName, ".go"), ".")
+	if !found || !strings.HasSuffix(fileName, ".go") || ValidateProfile(profile) != nil {
+		return fileName, ""
+	}
+
+	return base + ".go", profile
+}
+
+// For add a //go:build line above the package clause and the comments right before it
+func addBuildConstraint(content string, expr string) string {
+	lines := strings.Split(content, "\n")
+
+	index := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "package ") {
+			index = i
+			break
+		}
+	}
+	for index > 0 && strings.HasPrefix(lines[index-1], "//") {
+		index--
+	}
+
+	result := make([]string, 0, len(lines)+2)
+	result = append(result, lines[:index]...)
+	result = append(result, "//go:build "+expr, "")
+	result = append(result, lines[index:]...)
+
+	return strings.Join(result, "\n")
+}
+
+// For check if a line is a //go:build constraint that needs the wireinject tag,
+// the tag of a profile file, e.g. wireinject_dev, is another tag
+func IsInjectorConstraint(line string) bool {
+	if !constraint.IsGoBuild(line) {
+		return false
+	}
+
+	expr, err := constraint.Parse(line)
+	if err != nil {
+		return false
+	}
+
+	usesTag := false
+	needsTag := !expr.Eval(func(tag string) bool {
+		usesTag = usesTag || tag == "wireinject"
+		return tag != "wireinject"
+	})
+
+	return usesTag && needsTag
+}
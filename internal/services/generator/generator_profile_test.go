@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInjectorConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected bool
+	}{
+		{name: "Wireinject", line: "//go:build wireinject", expected: true},
+		{name: "Wireinject and an OS", line: "//go:build !windows && wireinject", expected: true},
+		{name: "Negated wireinject", line: "//go:build !wireinject", expected: false},
+		{name: "Profile tag", line: "//go:build wireinject_dev", expected: false},
+		{name: "Other tag", line: "//go:build linux", expected: false},
+		{name: "Not a constraint", line: "// wireinject", expected: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, IsInjectorConstraint(tt.line))
+		})
+	}
+}
+
+func TestSplitProfileFileName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		fileName         string
+		expectedFileName string
+		expectedProfile  string
+	}{
+		{fileName: "wire_set_gen.dev.go", expectedFileName: "wire_set_gen.go", expectedProfile: "dev"},
+		{fileName: "wire_set_gen.test.go", expectedFileName: "wire_set_gen.go", expectedProfile: "test"},
+		{fileName: "wire_set_gen.go", expectedFileName: "wire_set_gen.go"},
+		{fileName: "wire_set_gen.dev.local.go", expectedFileName: "wire_set_gen.dev.local.go"},
+		{fileName: "notes.dev.txt", expectedFileName: "notes.dev.txt"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.fileName, func(t *testing.T) {
+			t.Parallel()
+
+			fileName, profile := SplitProfileFileName(tt.fileName)
+
+			assert.Equal(t, tt.expectedFileName, fileName)
+			assert.Equal(t, tt.expectedProfile, profile)
+		})
+	}
+
+	assert.Equal(t, "wire_set_gen.dev.go", profileFileName(WireSetGenFileName, "dev"))
+	assert.True(t, IsGeneratedFileName("do_register_gen.prod.go"))
+}
+
+func Test_profileSetInfos(t *testing.T) {
+	t.Parallel()
+
+	config := &models.WireSetInfo{FunctionName: "NewConfig"}
+	memory := &models.WireSetInfo{FunctionName: "NewMemoryCache", Profiles: []string{"dev", "test"}}
+	redis := &models.WireSetInfo{FunctionName: "NewRedisCache", Profiles: []string{"prod"}}
+	setInfos := []*models.WireSetInfo{config, memory, redis}
+
+	assert.Equal(t, []*models.WireSetInfo{config}, profileSetInfos(setInfos, ""))
+	assert.Equal(t, []*models.WireSetInfo{config, memory}, profileSetInfos(setInfos, "test"))
+	assert.Equal(t, []*models.WireSetInfo{config, redis}, profileSetInfos(setInfos, "prod"))
+}
+
+func Test_locationProfiles(t *testing.T) {
+	t.Parallel()
+
+	config := &models.Config{Profiles: map[string][]string{"cmd/api": {"dev", "prod"}}}
+
+	assert.Equal(t, []string{"test"}, locationProfiles(&models.WireGenLocation{DirectoryPath: "cmd/api", Profiles: []string{"test"}}, config))
+	assert.Equal(t, []string{"dev", "prod"}, locationProfiles(&models.WireGenLocation{DirectoryPath: "cmd/api"}, config))
+	assert.Equal(t, []string{""}, locationProfiles(&models.WireGenLocation{DirectoryPath: "cmd/worker"}, config))
+}
+
+func Test_addBuildConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "After the generated header",
+			content:  "// Code generated by go-wireset-gen. DO NOT EDIT.\n\npackage app\n",
+			expected: "// Code generated by go-wireset-gen. DO NOT EDIT.\n\n//go:build wireinject_dev\n\npackage app\n",
+		},
+		{
+			name:     "Above the package comment",
+			content:  "// Code generated by go-wireset-gen. DO NOT EDIT.\n\n// Package app is generated.\npackage app\n",
+			expected: "// Code generated by go-wireset-gen. DO NOT EDIT.\n\n//go:build wireinject_dev\n\n// Package app is generated.\npackage app\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, addBuildConstraint(tt.content, "wireinject_dev"))
+		})
+	}
+}
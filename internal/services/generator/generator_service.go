@@ -2,183 +2,642 @@ package generator
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"sort"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/graphzc/wiresetgen/internal/models"
+	cacheRepo "github.com/graphzc/wiresetgen/internal/repositories/cache"
 	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
-	"github.com/graphzc/wiresetgen/internal/templates"
+	"github.com/graphzc/wiresetgen/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
+const WireSetGenFileName = "wire_set_gen.go"
+
+// A file is parsed only when it contains one of these markers
+var scanMarkers = []string{AnnotationMarker, SetDirective, "wireinject"}
+
 type Service interface {
-	GenerateWireSet(verbose bool) error
+	GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*models.GenerateResult, error)
+	ScanProject(ctx context.Context, options *models.GenerateOptions) (*models.ProjectScan, error)
+	ListGoFiles(ctx context.Context, jobs int) ([]string, error)
+	LoadConfig() (*models.Config, error)
+	ScanFile(moduleName string, filePath string, verbose bool) (*models.FileScan, error)
+	RenderWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error)
+	WriteWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error)
+	CleanCache() error
 }
 
 type generatorServiceImpl struct {
-	fileRepository fileRepo.Repository
+	fileRepository  fileRepo.Repository
+	cacheRepository cacheRepo.Repository
 }
 
-func NewGenerateService(fileRepository fileRepo.Repository) Service {
+func NewGenerateService(fileRepository fileRepo.Repository, cacheRepository cacheRepo.Repository) Service {
 	return &generatorServiceImpl{
-		fileRepository: fileRepository,
+		fileRepository:  fileRepository,
+		cacheRepository: cacheRepository,
 	}
 }
 
-func (g *generatorServiceImpl) GenerateWireSet(verbose bool) error {
-	// Check if the current directory is a Go project root
-	goModFile, err := g.fileRepository.GetGoModFile()
+func (g *generatorServiceImpl) GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*models.GenerateResult, error) {
+	scan, err := g.ScanProject(ctx, options)
 	if err != nil {
-		if errors.Is(err, fileRepo.ErrFileNotFound) {
-			return ErrIsNotProjectRoot
-		}
+		return nil, err
+	}
 
-		return err
+	config, err := g.LoadConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	// Try to read module name from go.mod file
-	moduleName, err := getModuleName(goModFile)
+	generatedFiles, err := g.renderFiles(scan, scan.WireGenLocations, config, options)
 	if err != nil {
-		return ErrInvalidGoModFile
+		return nil, err
 	}
 
-	// List all Go files in the project
-	goFiles, err := g.fileRepository.ListAllGoFiles()
+	if !options.DryRun {
+		if err := g.writeFiles(generatedFiles, options.Verbose); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.GenerateResult{
+		Scan:  scan,
+		Files: generatedFiles,
+	}, nil
+}
+
+func (g *generatorServiceImpl) WriteWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+	generatedFiles, err := g.RenderWireSets(scan, wireGenLocations, options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	allSetInfo := make([]*models.WireSetInfo, 0, 64)
-	allWireGenLocation := make([]*models.WireGenLocation, 0, 4)
+	if err := g.writeFiles(generatedFiles, options.Verbose); err != nil {
+		return nil, err
+	}
+
+	return generatedFiles, nil
+}
+
+func (g *generatorServiceImpl) writeFiles(generatedFiles []*models.GeneratedFile, verbose bool) error {
+	directories := make([]string, 0)
+	written := make(map[string]map[string]bool)
 
-	for _, file := range goFiles {
-		fileContent, err := g.fileRepository.ReadFile(file)
+	for _, generatedFile := range generatedFiles {
+		// Write the generated file
+		err := g.fileRepository.WriteFile(generatedFile.DirectoryPath, generatedFile.FileName, generatedFile.Content)
 		if err != nil {
 			return err
 		}
 
-		extractedWireGenLocation, err := extractWireGenLocation(file, string(fileContent))
+		if verbose {
+			logrus.Infof("Generated wire set file at %s/%s\n", generatedFile.DirectoryPath, generatedFile.FileName)
+		}
+
+		if written[generatedFile.DirectoryPath] == nil {
+			written[generatedFile.DirectoryPath] = make(map[string]bool)
+			directories = append(directories, generatedFile.DirectoryPath)
+		}
+		written[generatedFile.DirectoryPath][generatedFile.FileName] = true
+	}
+
+	return g.removeStaleFiles(directories, written)
+}
+
+// For remove the generated files of a written location that the run no longer produces, e.g. the file
+// without profile after a //wiresetgen:profile directive, the file of a renamed set or of another backend,
+// they declare the same variables as the new files and the package would not build
+func (g *generatorServiceImpl) removeStaleFiles(directories []string, written map[string]map[string]bool) error {
+	for _, directory := range directories {
+		fileNames, err := g.fileRepository.ListFileNames(directory)
 		if err != nil {
 			return err
 		}
-		if extractedWireGenLocation != nil {
-			allWireGenLocation = append(allWireGenLocation, extractedWireGenLocation)
 
-			if verbose {
-				logrus.Info("Found wire gen file at", file)
+		for _, fileName := range fileNames {
+			if !IsGeneratedFileName(fileName) || written[directory][fileName] {
+				continue
 			}
 
-			// If this file is wire gen file, skip extracting set info
-			continue
-		}
-
-		extractedSetInfos := extractSetInfo(moduleName, file, fileContent)
-		if len(extractedSetInfos) > 0 {
-			if verbose {
-				for _, setInfo := range extractedSetInfos {
-					logrus.Infof("Found wire set %s for function %s\n", setInfo.SetName, setInfo.FunctionName)
-				}
+			if err := g.fileRepository.RemoveFile(directory, fileName); err != nil {
+				return err
 			}
 
-			allSetInfo = append(allSetInfo, extractedSetInfos...)
+			logrus.Infof("Removed stale generated file %s\n", path.Join(filepath.ToSlash(directory), fileName))
 		}
 	}
 
-	// Create import map
-	// Create a map[importPath]alias
-	importMap := make(map[string]string)
-	aliasCounts := make(map[string]int)
+	return nil
+}
+
+// For render the files with the backends of the config, the backend and the template of the options win over it
+func (g *generatorServiceImpl) RenderWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+	config, err := g.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return g.renderFiles(scan, wireGenLocations, config, options)
+}
+
+// Every backend shares the extraction and the import aliases, only the template differs
+// The backend and the template of the options win over the config
+func (g *generatorServiceImpl) renderFiles(
+	scan *models.ProjectScan,
+	wireGenLocations []*models.WireGenLocation,
+	config *models.Config,
+	options *models.GenerateOptions,
+) ([]*models.GeneratedFile, error) {
+	// A provider declared once per platform is one provider built with any of the constraints
+	allSetInfo := mergeConstrainedSetInfos(scan.SetInfos)
+
+	// The package names of the providers are known, a standard library package is named after its path
+	packageNames := make(map[string]string)
+	types := make([]string, 0)
 	for _, setInfo := range allSetInfo {
-		if _, exists := importMap[setInfo.ImportPath]; exists {
-			continue
+		packageNames[setInfo.ImportPath] = setInfo.PackageName
+		types = append(types, setInfo.ParamTypes...)
+		types = append(types, setInfo.ResultTypes...)
+		if setInfo.Bind != "" {
+			types = append(types, setInfo.Bind)
+		}
+	}
+	for _, importPath := range TypeImportPaths(types) {
+		if _, known := packageNames[importPath]; !known && isStandardImportPath(importPath, scan.ModuleName) {
+			packageNames[importPath] = DefaultImportName(importPath)
+		}
+	}
+
+	// The alias function is bound to the imports of each location before it is rendered
+	customTemplate, err := g.loadTemplate(options.Template, config, templateFuncs(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	// The backend templates are parsed once, the first time a location uses them
+	backendTemplates := make(map[string]*template.Template)
+
+	generatedFiles := make([]*models.GeneratedFile, 0, len(wireGenLocations))
+	for _, wireGenLocation := range wireGenLocations {
+		selected, err := locationBackend(wireGenLocation, config, options.Backend)
+		if err != nil {
+			return nil, err
 		}
 
-		importPathParts := strings.Split(setInfo.ImportPath, "/")
+		if options.Verbose {
+			logrus.Infof("Generating %s sets for %s\n", selected.name, wireGenLocation.DirectoryPath)
+		}
+
+		tmpl := customTemplate
+		if tmpl == nil {
+			tmpl = backendTemplates[selected.name]
+		}
+		if tmpl == nil {
+			tmpl, err = template.New(selected.name).Funcs(templateFuncs(nil)).Parse(selected.template)
+			if err != nil {
+				return nil, err
+			}
+			backendTemplates[selected.name] = tmpl
+		}
+
+		localImportPath := path.Join(scan.ModuleName, filepath.ToSlash(wireGenLocation.DirectoryPath))
+
+		// Only the packages the file writes get an alias, so an unused package never takes a name
+		reserved, err := g.reservedNames(wireGenLocation, localImportPath, scan.SetInfos, backendNaming(&config.Naming, selected))
+		if err != nil {
+			return nil, err
+		}
 
-		alias := importPathParts[len(importPathParts)-1]
-		if _, exists := aliasCounts[alias]; exists {
-			aliasCounts[alias]++
-			alias = fmt.Sprintf("%s%d", alias, aliasCounts[alias])
-		} else {
-			aliasCounts[alias] = 1
+		// The output of a custom template may use the declarations of the location package,
+		// the Build function must not redeclare one of them
+		var declared map[string]bool
+		if customTemplate != nil || selected.build {
+			declared, err = g.packageDeclarations(wireGenLocation.DirectoryPath)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if selected.build && declared[BuildFuncName] {
+			return nil, fmt.Errorf("%w: %s in %s, the %s backend generates it", ErrDeclaredName,
+				BuildFuncName, wireGenLocation.DirectoryPath, selected.name)
 		}
 
-		importMap[setInfo.ImportPath] = alias
+		profiles := locationProfiles(wireGenLocation, config)
+		for _, profile := range profiles {
+			// Several profiles get a file each, built only with the tag of the profile
+			buildTag, label := "", wireGenLocation.DirectoryPath
+			if len(profiles) > 1 {
+				buildTag = ProfileTagPrefix + profile
+			}
+			if profile != "" {
+				label = fmt.Sprintf("%s (%s)", wireGenLocation.DirectoryPath, profile)
+			}
+
+			// The sets of an internal package of another tree cannot be imported here
+			setInfos, skipped, err := visibleSetInfos(profileSetInfos(allSetInfo, profile), selected, localImportPath)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", label, err)
+			}
+
+			for _, reason := range skipped {
+				logrus.Warnf("%s: skipping %s\n", label, reason)
+			}
+
+			// The sets whose providers are built only with some constraint get a file each
+			for i, constrained := range splitConstrainedSets(setInfos, selected, locationConstraint(wireGenLocation, config)) {
+				fileName := setFileName(selected.fileName, constrained.suffix)
+				if len(profiles) > 1 {
+					fileName = profileFileName(fileName, profile)
+				}
+
+				if options.Verbose && (profile != "" || constrained.suffix != "") {
+					logrus.Infof("Rendering %s to %s\n", label, fileName)
+				}
+
+				generatedFile, err := g.renderFile(&locationFile{
+					location:        wireGenLocation,
+					backend:         selected,
+					template:        tmpl,
+					custom:          customTemplate != nil,
+					fileName:        fileName,
+					label:           label,
+					profile:         profile,
+					constraint:      andConstraints(buildTag, constrained.constraint),
+					setInfos:        constrained.setInfos,
+					localImportPath: localImportPath,
+					moduleName:      scan.ModuleName,
+					packageNames:    packageNames,
+					reserved:        reserved,
+					declared:        declared,
+				}, &config.Naming, options.Verbose)
+				if err != nil {
+					return nil, err
+				}
+
+				// The skipped sets are reported once for the location
+				if i == 0 {
+					generatedFile.Skipped = skipped
+				}
+				generatedFiles = append(generatedFiles, generatedFile)
+			}
+		}
 	}
 
-	// Convert import map to []models.ImportTemplate
-	imports := make([]*models.ImportTemplate, 0, len(importMap))
-	for importPath, alias := range importMap {
-		imports = append(imports, &models.ImportTemplate{
-			Alias: alias,
-			Path:  importPath,
-		})
+	return generatedFiles, nil
+}
+
+// A generated file of a location with the providers it declares
+type locationFile struct {
+	location        *models.WireGenLocation
+	backend         *backend
+	template        *template.Template
+	custom          bool
+	fileName        string
+	label           string
+	profile         string
+	constraint      string
+	setInfos        []*models.WireSetInfo
+	localImportPath string
+	moduleName      string
+	packageNames    map[string]string
+	reserved        map[string]bool
+	declared        map[string]bool
+}
+
+// For render a file of a location with the imports its providers need
+func (g *generatorServiceImpl) renderFile(file *locationFile, naming *models.NamingConfig, verbose bool) (*models.GeneratedFile, error) {
+	selected := file.backend
+	importMap := importAliases(backendImportPaths(file.setInfos, selected, file.localImportPath), file.packageNames, file.reserved)
+
+	locationTemplate, err := file.template.Clone()
+	if err != nil {
+		return nil, err
 	}
+	locationTemplate.Funcs(templateFuncs(importMap))
 
-	// Sort imports by import path
-	sort.Slice(imports, func(i, j int) bool {
-		return imports[i].Path < imports[j].Path
-	})
+	wireSets, imports, err := newWireSets(file.setInfos, selected, naming, file.localImportPath, importMap)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file.label, err)
+	}
 
-	// Convert allSetInfo to map[setName][]*wireSetInfo
-	setInfoMap := make(map[string][]*models.WireSetInfo)
-	for _, setInfo := range allSetInfo {
-		setInfoMap[setInfo.SetName] = append(setInfoMap[setInfo.SetName], setInfo)
+	if verbose {
+		for _, wireSet := range wireSets {
+			logrus.Infof("Rendering wire set %s as %s\n", wireSet.SetName, wireSet.VarName)
+		}
 	}
 
-	for _, wireGenLocation := range allWireGenLocation {
-		if verbose {
-			logrus.Infof("Generating wire set for %s\n", wireGenLocation.DirectoryPath)
+	templateModel := &models.WireSetGenTemplateModel{
+		PackageName:   file.location.PackageName,
+		Imports:       imports,
+		WireSets:      wireSets,
+		ModuleName:    file.moduleName,
+		ImportPath:    file.localImportPath,
+		DirectoryPath: file.location.DirectoryPath,
+		InjectorFile:  file.location.FilePath,
+		FileName:      file.fileName,
+		Backend:       selected.name,
+		Profile:       file.profile,
+		Constraint:    file.constraint,
+	}
+
+	// The Build function calls the providers of the location package without the import
+	if selected.build {
+		templateModel.Build, templateModel.Imports, err = newBuildTemplateModel(file.setInfos, file.localImportPath, importMap)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.label, err)
 		}
+	}
+
+	omitAliases(templateModel.Imports, file.packageNames)
 
-		wireSetGenTemplate := templates.WireSetGenTemplate
-		tmpl, err := template.New("wireSetGen").Parse(wireSetGenTemplate)
+	var buf bytes.Buffer
+	if err = locationTemplate.Execute(&buf, templateModel); err != nil {
+		return nil, err
+	}
+
+	// The built-in templates are trusted, the output of a custom one is checked and always formatted
+	if file.custom {
+		filePath := filepath.Join(file.location.DirectoryPath, file.fileName)
+		if err := validateGeneratedFile(filePath, file.location.PackageName, buf.Bytes(), file.packageNames, file.declared); err != nil {
+			return nil, err
+		}
+	}
+
+	content := buf.String()
+	if selected.format || file.custom {
+		formatted, err := format.Source(buf.Bytes())
 		if err != nil {
-			return err
+			return nil, err
 		}
+		content = string(formatted)
+	}
+	if file.constraint != "" {
+		content = addBuildConstraint(content, file.constraint)
+	}
 
-		wireSetsMap := make(map[string]*models.WireSet, 0)
-		for setName, setInfos := range setInfoMap {
-			wireSetsMap[setName] = &models.WireSet{
-				SetName: setName,
-			}
+	return &models.GeneratedFile{
+		DirectoryPath: file.location.DirectoryPath,
+		FileName:      file.fileName,
+		Content:       content,
+	}, nil
+}
+
+// For get the names an import of the generated file must not take: the location package name, the generated
+// declarations and the declarations of the injector file and of the provider files of the location package,
+// that are built together with the generated file
+func (g *generatorServiceImpl) reservedNames(
+	wireGenLocation *models.WireGenLocation,
+	localImportPath string,
+	setInfos []*models.WireSetInfo,
+	naming *models.NamingConfig,
+) (map[string]bool, error) {
+	filePaths := []string{wireGenLocation.FilePath}
+	for _, setInfo := range setInfos {
+		if setInfo.ImportPath == localImportPath && !slices.Contains(filePaths, setInfo.FilePath) {
+			filePaths = append(filePaths, setInfo.FilePath)
+		}
+	}
+
+	// A file that is being edited may not parse, the declarations before the error are still known
+	reserved := make(map[string]bool)
+	for _, filePath := range filePaths {
+		content, err := g.fileRepository.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
 
-			for _, info := range setInfos {
-				wireSetsMap[setName].FuncPath = append(wireSetsMap[setName].FuncPath, fmt.Sprintf("%s.%s", importMap[info.ImportPath], info.FunctionName))
+		if file, _ := parser.ParseFile(token.NewFileSet(), filePath, content, parser.SkipObjectResolution); file != nil {
+			for name := range TopLevelNames(file) {
+				reserved[name] = true
 			}
 		}
+	}
+
+	reserved[wireGenLocation.PackageName] = true
+	reserved[BuildFuncName] = true
+	for _, setInfo := range setInfos {
+		varName := SetVarName(setInfo.SetName, naming)
+		reserved[varName] = true
+		reserved["Register"+varName] = true
+		reserved["Provide"+varName] = true
+	}
 
-		// Convert wireSetsMap to slice and sort by set name
-		wireSets := make([]*models.WireSet, 0, len(wireSetsMap))
-		for _, wireSet := range wireSetsMap {
-			wireSets = append(wireSets, wireSet)
+	return reserved, nil
+}
+
+// For get the top-level names of the files of a package directory, test and generated files are left out
+func (g *generatorServiceImpl) packageDeclarations(directory string) (map[string]bool, error) {
+	fileNames, err := g.fileRepository.ListFileNames(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool)
+	for _, fileName := range fileNames {
+		if filepath.Ext(fileName) != ".go" || strings.HasSuffix(fileName, "_test.go") || IsGeneratedFileName(fileName) {
+			continue
 		}
-		sort.Slice(wireSets, func(i, j int) bool {
-			return wireSets[i].SetName < wireSets[j].SetName
-		})
 
-		var buf bytes.Buffer
-		if err = tmpl.Execute(&buf, models.WireSetGenTemplateModel{
-			PackageName: wireGenLocation.PackageName,
-			Imports:     imports,
-			WireSets:    wireSets,
-		}); err != nil {
-			return err
+		filePath := filepath.Join(directory, fileName)
+		content, err := g.fileRepository.ReadFile(filePath)
+		if err != nil {
+			return nil, err
 		}
 
-		// Write the generated file
-		err = g.fileRepository.WriteFile(wireGenLocation.DirectoryPath, "wire_set_gen.go", buf.String())
+		// A file that is being edited may not parse, the declarations before the error are still known
+		if file, _ := parser.ParseFile(token.NewFileSet(), filePath, content, parser.SkipObjectResolution); file != nil {
+			for name := range TopLevelNames(file) {
+				declared[name] = true
+			}
+		}
+	}
+
+	return declared, nil
+}
+
+// For parse the custom template of the options or the config, nil when there is none
+func (g *generatorServiceImpl) loadTemplate(templatePath string, config *models.Config, funcs template.FuncMap) (*template.Template, error) {
+	if templatePath == "" {
+		templatePath = config.Template
+	}
+	if templatePath == "" {
+		return nil, nil
+	}
+
+	text, err := g.fileRepository.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, templatePath, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+func (g *generatorServiceImpl) ScanProject(ctx context.Context, options *models.GenerateOptions) (*models.ProjectScan, error) {
+	// Check if the current directory is a Go project root
+	goModFile, err := g.fileRepository.GetGoModFile()
+	if err != nil {
+		if errors.Is(err, fileRepo.ErrFileNotFound) {
+			return nil, ErrIsNotProjectRoot
+		}
+
+		return nil, err
+	}
+
+	// Try to read module name from go.mod file
+	moduleName, err := GetModuleName(goModFile)
+	if err != nil {
+		return nil, ErrInvalidGoModFile
+	}
+
+	// List all Go files in the project
+	goFiles, err := g.ListGoFiles(ctx, options.Jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files that did not change since the last run are taken from the cache
+	previousCache := g.loadScanCache(moduleName, options)
+	currentCache := newScanCache(moduleName)
+
+	// Scan the files concurrently, each worker writes only its own index
+	// so merging in the files order keeps the output the same as a sequential scan
+	fileScans := make([]*models.FileScan, len(goFiles))
+	cacheEntries := make([]*models.CachedFileScan, len(goFiles))
+
+	err = utils.ForEachParallel(ctx, options.Jobs, len(goFiles), func(ctx context.Context, i int) error {
+		fileScan, cacheEntry, err := g.scanFileWithCache(moduleName, goFiles[i], previousCache, options.Verbose)
 		if err != nil {
 			return err
 		}
 
-		if verbose {
-			logrus.Infof("Generated wire set file at %s/wire_set_gen.go\n", wireGenLocation.DirectoryPath)
+		fileScans[i] = fileScan
+		cacheEntries[i] = cacheEntry
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allSetInfo := make([]*models.WireSetInfo, 0, 64)
+	allWireGenLocation := make([]*models.WireGenLocation, 0, 4)
+	allWarnings := make([]string, 0)
+
+	for i, file := range goFiles {
+		fileScan := fileScans[i]
+		currentCache.Files[file] = cacheEntries[i]
+
+		if options.Verbose {
+			logFileScan(file, fileScan)
 		}
+
+		// The warnings are kept in the cache, so an unchanged file still reports them
+		for _, warning := range fileScan.Warnings {
+			logrus.Warnf("%s\n", warning)
+		}
+		allWarnings = append(allWarnings, fileScan.Warnings...)
+
+		if fileScan.WireGenLocation != nil {
+			allWireGenLocation = append(allWireGenLocation, fileScan.WireGenLocation)
+		}
+
+		allSetInfo = append(allSetInfo, fileScan.SetInfos...)
 	}
 
-	return nil
+	if !options.NoCache {
+		if err := g.cacheRepository.SaveScanCache(currentCache); err != nil && options.Verbose {
+			logrus.Warn("Cannot save scan cache:", err)
+		}
+	}
+
+	return &models.ProjectScan{
+		ModuleName:       moduleName,
+		SetInfos:         allSetInfo,
+		WireGenLocations: allWireGenLocation,
+		Warnings:         allWarnings,
+	}, nil
+}
+
+func (g *generatorServiceImpl) ScanFile(moduleName string, filePath string, verbose bool) (*models.FileScan, error) {
+	matched, err := g.fileRepository.ContainsAny(filePath, scanMarkers)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return &models.FileScan{}, nil
+	}
+
+	fileContent, err := g.fileRepository.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileScan, err := scanFileContent(moduleName, filePath, fileContent, verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	if verbose {
+		logFileScan(filePath, fileScan)
+	}
+
+	return fileScan, nil
+}
+
+func scanFileContent(moduleName string, filePath string, fileContent string, verbose bool) (*models.FileScan, error) {
+	extractedWireGenLocation, err := extractWireGenLocation(filePath, fileContent)
+	if err != nil {
+		return nil, err
+	}
+	if extractedWireGenLocation != nil {
+		// If this file is wire gen file, skip extracting set info
+		return &models.FileScan{
+			WireGenLocation: extractedWireGenLocation,
+		}, nil
+	}
+
+	extractedSetInfos, warnings, err := extractSetInfo(moduleName, filePath, fileContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signatures are best effort, a file that does not parse still contributes its sets
+	if err := extractFuncSignatures(filePath, fileContent, extractedSetInfos); err != nil && verbose {
+		logrus.Warnf("Cannot read provider signatures from %s: %v\n", filePath, err)
+	}
+
+	fileScan := &models.FileScan{
+		SetInfos: extractedSetInfos,
+	}
+	if len(warnings) > 0 {
+		fileScan.Warnings = warnings
+	}
+
+	return fileScan, nil
+}
+
+func logFileScan(filePath string, fileScan *models.FileScan) {
+	if fileScan.WireGenLocation != nil {
+		logrus.Info("Found wire gen file at", filePath)
+	}
+
+	for _, setInfo := range fileScan.SetInfos {
+		logrus.Infof("Found wire set %s for function %s\n", setInfo.SetName, setInfo.FunctionName)
+	}
 }
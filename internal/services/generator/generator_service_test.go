@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_cache "github.com/graphzc/wiresetgen/internal/repositories/cache/mock"
+	"github.com/graphzc/wiresetgen/internal/repositories/files"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScanProject(t *testing.T) {
+	t.Parallel()
+
+	goFiles := make([]string, 0, 20)
+	for i := range 20 {
+		goFiles = append(goFiles, fmt.Sprintf("service%d/service.go", i))
+	}
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().GetGoModFile().Return("module github.com/foo/bar\n", nil)
+	fileRepository.EXPECT().GetConfigFile().Return("", files.ErrFileNotFound)
+	fileRepository.EXPECT().ListAllGoFiles(mock.Anything, 4).Return(goFiles, nil)
+	fileRepository.EXPECT().StatFile(mock.Anything).Return(&models.FileStat{Size: 1, ModTime: time.Now()}, nil)
+	fileRepository.EXPECT().ContainsAny(mock.Anything, scanMarkers).Return(true, nil)
+	fileRepository.EXPECT().ReadFile(mock.Anything).RunAndReturn(func(filePath string) (string, error) {
+		return fmt.Sprintf("package service\n\n// @WireSet(\"Service\")\nfunc New%d() {}\n", len(filePath)), nil
+	})
+
+	service := NewGenerateService(fileRepository, mock_cache.NewRepository(t))
+
+	scan, err := service.ScanProject(context.Background(), &models.GenerateOptions{
+		NoCache: true,
+		Jobs:    4,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/foo/bar", scan.ModuleName)
+	if assert.Len(t, scan.SetInfos, len(goFiles)) {
+		for i, setInfo := range scan.SetInfos {
+			// The merged result keeps the listing order regardless of the worker scheduling
+			assert.Equal(t, goFiles[i], setInfo.FilePath)
+		}
+	}
+}
+
+func TestScanProject_firstError(t *testing.T) {
+	t.Parallel()
+
+	errRead := errors.New("read failed")
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().GetGoModFile().Return("module github.com/foo/bar\n", nil)
+	fileRepository.EXPECT().GetConfigFile().Return("", files.ErrFileNotFound)
+	fileRepository.EXPECT().ListAllGoFiles(mock.Anything, 2).Return([]string{"a.go", "b.go", "c.go"}, nil)
+	fileRepository.EXPECT().StatFile(mock.Anything).Return(&models.FileStat{Size: 1, ModTime: time.Now()}, nil).Maybe()
+	fileRepository.EXPECT().ContainsAny(mock.Anything, scanMarkers).Return(true, nil).Maybe()
+	fileRepository.EXPECT().ReadFile(mock.Anything).Return("", errRead).Maybe()
+
+	service := NewGenerateService(fileRepository, mock_cache.NewRepository(t))
+
+	scan, err := service.ScanProject(context.Background(), &models.GenerateOptions{
+		NoCache: true,
+		Jobs:    2,
+	})
+
+	assert.Nil(t, scan)
+	assert.ErrorIs(t, err, errRead)
+}
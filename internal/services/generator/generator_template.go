@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// For get the functions every generated file template can use
+func templateFuncs(importMap map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"join":          strings.Join,
+		"sort":          sortStrings,
+		"sortProviders": sortProviders,
+		"alias": func(importPath string) string {
+			if alias, exists := importMap[importPath]; exists {
+				return alias
+			}
+
+			return DefaultImportName(importPath)
+		},
+		"quote":      strconv.Quote,
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"lowerFirst": lowerFirst,
+		"upperFirst": upperFirst,
+		"camel":      camelCase,
+		"snake":      snakeCase,
+	}
+}
+
+// For sort a copy of the values, the template data is never changed
+func sortStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func sortProviders(providers []*models.ProviderTemplate) []*models.ProviderTemplate {
+	sorted := append([]*models.ProviderTemplate{}, providers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].FuncPath < sorted[j].FuncPath
+	})
+
+	return sorted
+}
+
+func lowerFirst(value string) string {
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return value
+	}
+
+	return string(unicode.ToLower(runes[0])) + string(runes[1:])
+}
+
+func upperFirst(value string) string {
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return value
+	}
+
+	return string(unicode.ToUpper(runes[0])) + string(runes[1:])
+}
+
+// For write the words of a name in camel case, e.g. user_repo and UserRepo become userRepo
+func camelCase(value string) string {
+	words := splitWords(value)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = upperFirst(strings.ToLower(word))
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// For write the words of a name in snake case, e.g. HTTPServer becomes http_server
+func snakeCase(value string) string {
+	words := splitWords(value)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// A word ends at a separator, before an upper case letter after a lower case one,
+// and before the last letter of an upper case run followed by a lower case one
+func splitWords(value string) []string {
+	words := make([]string, 0)
+	runes := []rune(value)
+
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// For check the output of a custom template before it is written, the file is not type-checked
+// It must parse, be in the location package, use every import and qualify only its imports or the names
+// declared by the other files of the package. The name of an import without alias is the package name
+// of packageNames, omitAliases drops the aliases it knows
+func validateGeneratedFile(filePath string, packageName string, content []byte, packageNames map[string]string, declared map[string]bool) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.AllErrors)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplateOutput, err)
+	}
+
+	if file.Name.Name != packageName {
+		return fmt.Errorf("%w: %s: package %s, expected %s", ErrInvalidTemplateOutput,
+			fset.Position(file.Name.Pos()), file.Name.Name, packageName)
+	}
+
+	importNames := make(map[string]bool)
+	for _, spec := range file.Imports {
+		if name := importSpecName(spec, packageNames); name != "_" && name != "." {
+			importNames[name] = false
+		}
+	}
+
+	// Identifiers of the file scope are resolved by the parser, a package qualifier never is
+	unresolved := make(map[*ast.Ident]bool)
+	for _, ident := range file.Unresolved {
+		unresolved[ident] = true
+	}
+
+	problems := make([]string, 0)
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := selector.X.(*ast.Ident)
+		if !ok || !unresolved[ident] {
+			return true
+		}
+
+		if _, exists := importNames[ident.Name]; exists {
+			importNames[ident.Name] = true
+		} else if !declared[ident.Name] {
+			problems = append(problems, fmt.Sprintf("%s: %s is not imported or declared by the package", fset.Position(ident.Pos()), ident.Name))
+		}
+
+		return true
+	})
+
+	for _, spec := range file.Imports {
+		if used, exists := importNames[importSpecName(spec, packageNames)]; exists && !used {
+			problems = append(problems, fmt.Sprintf("%s: %s imported and not used", fset.Position(spec.Pos()), spec.Path.Value))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n%s", ErrInvalidTemplateOutput, strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func importSpecName(spec *ast.ImportSpec, packageNames map[string]string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	importPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	if name, known := packageNames[importPath]; known {
+		return name
+	}
+
+	return DefaultImportName(importPath)
+}
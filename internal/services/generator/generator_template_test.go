@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_camelCase(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value         string
+		expectedCamel string
+		expectedSnake string
+	}{
+		{value: "ServiceSet", expectedCamel: "serviceSet", expectedSnake: "service_set"},
+		{value: "user_repo", expectedCamel: "userRepo", expectedSnake: "user_repo"},
+		{value: "HTTPServer", expectedCamel: "httpServer", expectedSnake: "http_server"},
+		{value: "github.com/foo/bar-baz", expectedCamel: "githubComFooBarBaz", expectedSnake: "github_com_foo_bar_baz"},
+		{value: "UserID", expectedCamel: "userId", expectedSnake: "user_id"},
+		{value: "", expectedCamel: "", expectedSnake: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expectedCamel, camelCase(tc.value))
+			assert.Equal(tt, tc.expectedSnake, snakeCase(tc.value))
+		})
+	}
+}
+
+func Test_validateGeneratedFile(t *testing.T) {
+	t.Parallel()
+
+	// The package clause of a module package may not match its directory
+	packageNames := map[string]string{"github.com/foo/bar/transport/http": "httpapi"}
+
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name: "Valid file",
+			content: `package wire
+
+import (
+	svc "github.com/foo/bar/service"
+	"github.com/google/wire"
+)
+
+var ServiceSet = wire.NewSet(svc.NewService)
+
+func build(s *svc.Service) *svc.Service { return s }
+`,
+		},
+		{
+			name: "Package name differs from the directory",
+			content: `package wire
+
+import (
+	"net/http"
+
+	"github.com/foo/bar/transport/http"
+	"github.com/google/wire"
+)
+
+var ServerSet = wire.NewSet(httpapi.NewServer, wire.Value(http.DefaultClient))
+`,
+		},
+		{
+			name:          "Unused import of a renamed package",
+			content:       "package wire\n\nimport \"github.com/foo/bar/transport/http\"\n\nvar http = 1\n",
+			expectedError: "wire/wire_set_gen.go:3:8: \"github.com/foo/bar/transport/http\" imported and not used",
+		},
+		{
+			name:    "Variable of another file",
+			content: "package wire\n\nvar Name = config.Name\n",
+		},
+		{
+			name:          "Syntax error",
+			content:       "package wire\n\nvar ServiceSet = wire.NewSet(\n",
+			expectedError: "wire/wire_set_gen.go:3:31: expected ')'",
+		},
+		{
+			name:          "Other package",
+			content:       "package main\n",
+			expectedError: "wire/wire_set_gen.go:1:9: package main, expected wire",
+		},
+		{
+			name:          "Missing import",
+			content:       "package wire\n\nvar ServiceSet = wire.NewSet()\n",
+			expectedError: "wire/wire_set_gen.go:3:18: wire is not imported or declared by the package",
+		},
+		{
+			name:          "Unused import",
+			content:       "package wire\n\nimport \"github.com/google/wire\"\n",
+			expectedError: "wire/wire_set_gen.go:3:8: \"github.com/google/wire\" imported and not used",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			err := validateGeneratedFile("wire/wire_set_gen.go", "wire", []byte(tc.content), packageNames, map[string]bool{"config": true})
+
+			if tc.expectedError == "" {
+				assert.NoError(tt, err)
+				return
+			}
+
+			assert.ErrorIs(tt, err, ErrInvalidTemplateOutput)
+			assert.ErrorContains(tt, err, tc.expectedError)
+		})
+	}
+}
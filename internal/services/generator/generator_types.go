@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// Matches a package qualified name of a scanned type, e.g. example.com/app/service.Service
+var qualifiedNamePattern = regexp.MustCompile(`([\w.\-~]+(?:/[\w.\-~]+)*)\.([A-Za-z_]\w*)`)
+
+// For get the type a provider builds, the cleanup and the error are not provided types
+func ProvidedType(resultTypes []string) string {
+	for _, resultType := range resultTypes {
+		if resultType != "error" && resultType != "func()" {
+			return resultType
+		}
+	}
+
+	return ""
+}
+
+func HasResult(setInfos []*models.WireSetInfo, resultType string) bool {
+	for _, setInfo := range setInfos {
+		for _, result := range setInfo.ResultTypes {
+			if result == resultType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// For collect the provider inputs that no provider of the sets builds, in the providers order
+// The interface of a bind is built by its provider
+func UnprovidedTypes(setInfos []*models.WireSetInfo) []string {
+	provided := make(map[string]bool)
+	for _, setInfo := range setInfos {
+		provided[ProvidedType(setInfo.ResultTypes)] = true
+		if setInfo.Bind != "" {
+			provided[setInfo.Bind] = true
+		}
+	}
+
+	paramTypes := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, setInfo := range setInfos {
+		for _, paramType := range setInfo.ParamTypes {
+			if provided[paramType] || seen[paramType] {
+				continue
+			}
+
+			seen[paramType] = true
+			paramTypes = append(paramTypes, paramType)
+		}
+	}
+
+	return paramTypes
+}
+
+// For get the inputs a provider is called with, variadic inputs are optional and left out
+func providerParamTypes(setInfo *models.WireSetInfo) []string {
+	paramTypes := make([]string, 0, len(setInfo.ParamTypes))
+	for _, paramType := range setInfo.ParamTypes {
+		if !strings.HasPrefix(paramType, "...") {
+			paramTypes = append(paramTypes, paramType)
+		}
+	}
+
+	return paramTypes
+}
+
+// For get the import paths used by the scanned types
+func TypeImportPaths(scannedTypes []string) []string {
+	importPaths := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, scannedType := range scannedTypes {
+		for _, match := range qualifiedNamePattern.FindAllStringSubmatch(scannedType, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				importPaths = append(importPaths, match[1])
+			}
+		}
+	}
+
+	return importPaths
+}
+
+// For write a scanned type as source, the local package is not qualified
+func SourceType(scannedType string, localImportPath string, importNames map[string]string) string {
+	return qualifiedNamePattern.ReplaceAllStringFunc(scannedType, func(qualifiedName string) string {
+		match := qualifiedNamePattern.FindStringSubmatch(qualifiedName)
+		if match[1] == localImportPath {
+			return match[2]
+		}
+
+		return importNames[match[1]] + "." + match[2]
+	})
+}
+
+// For lower the leading initialism as a whole, e.g. DB becomes db and HTTPClient becomes httpClient
+func lowerInitialism(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
+
+// For name a variable after its type, e.g. *config.Config becomes config
+func TypeVarName(paramType string, usedNames map[string]bool) string {
+	name := paramType
+	if index := strings.LastIndexAny(name, "./*]"); index >= 0 {
+		name = name[index+1:]
+	}
+
+	name = lowerInitialism(name)
+
+	if !token.IsIdentifier(name) {
+		name = "p"
+	}
+
+	// A predeclared type name would shadow the type, e.g. string becomes stringValue
+	if predeclaredTypes[name] {
+		name += "Value"
+	}
+
+	candidate := name
+	for i := 2; usedNames[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	usedNames[candidate] = true
+
+	return candidate
+}
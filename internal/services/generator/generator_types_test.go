@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var typeTestSetInfos = []*models.WireSetInfo{
+	{
+		SetName:      "Repository",
+		FunctionName: "NewRepository",
+		ImportPath:   "github.com/foo/bar/repo",
+		ParamTypes:   []string{"*database/sql.DB"},
+		ResultTypes:  []string{"*github.com/foo/bar/repo.Repository"},
+	},
+	{
+		SetName:      "Service",
+		FunctionName: "NewService",
+		ImportPath:   "github.com/foo/bar/service",
+		ParamTypes:   []string{"*github.com/foo/bar/repo.Repository", "*database/sql.DB", "github.com/foo/bar/config.Config"},
+		ResultTypes:  []string{"*github.com/foo/bar/service.Service", "func()", "error"},
+	},
+	{
+		SetName:      "App",
+		FunctionName: "NewApp",
+		ImportPath:   "github.com/foo/bar/app",
+		ParamTypes:   []string{"*github.com/foo/bar/service.Service"},
+		ResultTypes:  []string{"*github.com/foo/bar/app.App"},
+	},
+}
+
+func TestUnprovidedTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"*database/sql.DB", "github.com/foo/bar/config.Config"}, UnprovidedTypes(typeTestSetInfos))
+	assert.Equal(t, []string{"*github.com/foo/bar/service.Service"}, UnprovidedTypes(typeTestSetInfos[2:]))
+}
+
+func TestHasResult(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, HasResult(typeTestSetInfos, "error"))
+	assert.True(t, HasResult(typeTestSetInfos, "func()"))
+	assert.False(t, HasResult(typeTestSetInfos[:1], "error"))
+}
+
+func TestSourceType(t *testing.T) {
+	t.Parallel()
+
+	importNames := map[string]string{
+		"database/sql":           "sql",
+		"github.com/foo/bar/svc": "service",
+	}
+
+	testCases := []struct {
+		name        string
+		scannedType string
+		expected    string
+	}{
+		{
+			name:        "Pointer",
+			scannedType: "*database/sql.DB",
+			expected:    "*sql.DB",
+		},
+		{
+			name:        "Aliased import",
+			scannedType: "[]github.com/foo/bar/svc.Service",
+			expected:    "[]service.Service",
+		},
+		{
+			name:        "Local package",
+			scannedType: "*github.com/foo/bar/app.App",
+			expected:    "*App",
+		},
+		{
+			name:        "Map",
+			scannedType: "map[string]*database/sql.DB",
+			expected:    "map[string]*sql.DB",
+		},
+		{
+			name:        "Builtin",
+			scannedType: "func()",
+			expected:    "func()",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, SourceType(tt.scannedType, "github.com/foo/bar/app", importNames))
+		})
+	}
+}
+
+func TestTypeVarName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		paramType string
+		usedNames map[string]bool
+		expected  string
+	}{
+		{
+			name:      "Pointer",
+			paramType: "*github.com/foo/bar/config.Config",
+			usedNames: map[string]bool{},
+			expected:  "config",
+		},
+		{
+			name:      "Initialism",
+			paramType: "*database/sql.DB",
+			usedNames: map[string]bool{},
+			expected:  "db",
+		},
+		{
+			name:      "Leading initialism",
+			paramType: "*net/http.HTTPClient",
+			usedNames: map[string]bool{},
+			expected:  "httpClient",
+		},
+		{
+			name:      "Used name",
+			paramType: "github.com/foo/bar/config.Config",
+			usedNames: map[string]bool{"config": true},
+			expected:  "config2",
+		},
+		{
+			name:      "Keyword",
+			paramType: "github.com/foo/bar/kind.Func",
+			usedNames: map[string]bool{},
+			expected:  "p",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, TypeVarName(tt.paramType, tt.usedNames))
+		})
+	}
+}
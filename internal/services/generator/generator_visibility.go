@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// For get the tree an import path is internal to, the parent of its last internal element
+// A path without an internal element can be imported from anywhere
+func InternalRoot(importPath string) (string, bool) {
+	elements := strings.Split(importPath, "/")
+	for i := len(elements) - 1; i >= 0; i-- {
+		if elements[i] == "internal" {
+			return strings.Join(elements[:i], "/"), true
+		}
+	}
+
+	return "", false
+}
+
+// For check the internal visibility rule of the go command, a package can import an internal
+// package only when it is in the tree rooted at the parent of the internal directory
+func CanImport(importPath string, fromImportPath string) bool {
+	root, internal := InternalRoot(importPath)
+	if !internal {
+		return true
+	}
+
+	return fromImportPath == root || strings.HasPrefix(fromImportPath, root+"/")
+}
+
+// For keep the providers the location can import, with the packages the backend writes for them
+// A set that the location cannot import at all is skipped with the reason, a set it can only import
+// in part would be incomplete and is an error
+func visibleSetInfos(
+	setInfos []*models.WireSetInfo,
+	selected *backend,
+	localImportPath string,
+) ([]*models.WireSetInfo, []string, error) {
+	hidden := make(map[string]string)
+	for _, setInfo := range setInfos {
+		if _, exists := hidden[setInfo.SetName]; exists {
+			continue
+		}
+
+		if reason := hiddenReason(setInfo, selected, localImportPath); reason != "" {
+			hidden[setInfo.SetName] = reason
+		}
+	}
+
+	visible := make([]*models.WireSetInfo, 0, len(setInfos))
+	visibleSets := make(map[string]bool)
+	for _, setInfo := range setInfos {
+		if _, exists := hidden[setInfo.SetName]; !exists {
+			visible = append(visible, setInfo)
+			continue
+		}
+
+		if hiddenReason(setInfo, selected, localImportPath) == "" {
+			visibleSets[setInfo.SetName] = true
+		}
+	}
+
+	skipped := make([]string, 0, len(hidden))
+	for setName, reason := range hidden {
+		if visibleSets[setName] {
+			return nil, nil, fmt.Errorf("%w: set %s is only in part visible from %s, %s", ErrInternalPackage, setName, localImportPath, reason)
+		}
+
+		skipped = append(skipped, fmt.Sprintf("set %s: %s", setName, reason))
+	}
+	sort.Strings(skipped)
+
+	return visible, skipped, nil
+}
+
+// For tell why the location cannot import a provider, empty when it can
+func hiddenReason(setInfo *models.WireSetInfo, selected *backend, localImportPath string) string {
+	importPaths := []string{setInfo.ImportPath}
+	if selected.providerTypes != nil {
+		importPaths = append(importPaths, TypeImportPaths(selected.providerTypes(setInfo))...)
+	}
+
+	for _, importPath := range importPaths {
+		if !CanImport(importPath, localImportPath) {
+			root, _ := InternalRoot(importPath)
+			return fmt.Sprintf("%s.%s uses %s, that is internal to %s", setInfo.PackageName, setInfo.FunctionName, importPath, root)
+		}
+	}
+
+	return ""
+}
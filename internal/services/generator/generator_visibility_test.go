@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanImport(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		importPath     string
+		fromImportPath string
+		expected       bool
+	}{
+		{name: "Not internal", importPath: "github.com/foo/bar/services/billing", fromImportPath: "github.com/foo/bar/cmd/api", expected: true},
+		{name: "Module internal", importPath: "github.com/foo/bar/internal/store", fromImportPath: "github.com/foo/bar/cmd/api", expected: true},
+		{name: "Parent of internal", importPath: "github.com/foo/bar/billing/internal/store", fromImportPath: "github.com/foo/bar/billing", expected: true},
+		{name: "Inside the tree", importPath: "github.com/foo/bar/billing/internal/store", fromImportPath: "github.com/foo/bar/billing/cmd/worker", expected: true},
+		{name: "Outside the tree", importPath: "github.com/foo/bar/billing/internal/store", fromImportPath: "github.com/foo/bar/cmd/api", expected: false},
+		{name: "Prefix is not a parent", importPath: "github.com/foo/bar/billing/internal/store", fromImportPath: "github.com/foo/bar/billingv2", expected: false},
+		{name: "Internal package itself", importPath: "github.com/foo/bar/billing/internal", fromImportPath: "github.com/foo/bar/cmd/api", expected: false},
+		{name: "Last internal element", importPath: "github.com/foo/bar/internal/billing/internal/store", fromImportPath: "github.com/foo/bar/internal/api", expected: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, CanImport(tt.importPath, tt.fromImportPath))
+		})
+	}
+}
+
+func Test_visibleSetInfos(t *testing.T) {
+	t.Parallel()
+
+	store := &models.WireSetInfo{
+		PackageName:  "store",
+		SetName:      "Store",
+		FunctionName: "NewStore",
+		ImportPath:   "github.com/foo/bar/billing/internal/store",
+		ResultTypes:  []string{"*github.com/foo/bar/billing/internal/store.Store"},
+	}
+	billing := &models.WireSetInfo{
+		PackageName:  "billing",
+		SetName:      "Billing",
+		FunctionName: "NewBilling",
+		ImportPath:   "github.com/foo/bar/billing",
+		ParamTypes:   []string{"*github.com/foo/bar/billing/internal/store.Store"},
+		ResultTypes:  []string{"*github.com/foo/bar/billing.Billing"},
+	}
+	reader := &models.WireSetInfo{
+		PackageName:  "store",
+		SetName:      "Billing",
+		FunctionName: "NewReader",
+		ImportPath:   "github.com/foo/bar/billing/internal/store",
+		ResultTypes:  []string{"*github.com/foo/bar/billing/internal/store.Reader"},
+	}
+
+	testCases := []struct {
+		name            string
+		backend         string
+		setInfos        []*models.WireSetInfo
+		localImportPath string
+		expected        []*models.WireSetInfo
+		expectedSkipped []string
+		expectedError   error
+	}{
+		{
+			name:            "Inside the tree",
+			backend:         BackendWire,
+			setInfos:        []*models.WireSetInfo{store, billing},
+			localImportPath: "github.com/foo/bar/billing/cmd/worker",
+			expected:        []*models.WireSetInfo{store, billing},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "Wire does not write the parameter types",
+			backend:         BackendWire,
+			setInfos:        []*models.WireSetInfo{store, billing},
+			localImportPath: "github.com/foo/bar/cmd/api",
+			expected:        []*models.WireSetInfo{billing},
+			expectedSkipped: []string{"set Store: store.NewStore uses github.com/foo/bar/billing/internal/store, that is internal to github.com/foo/bar/billing"},
+		},
+		{
+			name:            "Do writes the parameter types",
+			backend:         BackendDo,
+			setInfos:        []*models.WireSetInfo{store, billing},
+			localImportPath: "github.com/foo/bar/cmd/api",
+			expected:        []*models.WireSetInfo{},
+			expectedSkipped: []string{
+				"set Billing: billing.NewBilling uses github.com/foo/bar/billing/internal/store, that is internal to github.com/foo/bar/billing",
+				"set Store: store.NewStore uses github.com/foo/bar/billing/internal/store, that is internal to github.com/foo/bar/billing",
+			},
+		},
+		{
+			name:            "Set visible in part",
+			backend:         BackendWire,
+			setInfos:        []*models.WireSetInfo{billing, reader},
+			localImportPath: "github.com/foo/bar/cmd/api",
+			expectedError:   ErrInternalPackage,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, err := getBackend(tt.backend)
+			assert.NoError(t, err)
+
+			visible, skipped, err := visibleSetInfos(tt.setInfos, selected, tt.localImportPath)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, visible)
+			assert.Equal(t, tt.expectedSkipped, skipped)
+		})
+	}
+}
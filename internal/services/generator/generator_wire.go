@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"go/ast"
+	"strconv"
+)
+
+// The wire package, shared by the commands and the analyzer that read wire calls
+const WireImportPath = "github.com/google/wire"
+
+// For get the local name of an import of the file, empty when the file does not import it
+// An import without alias is named as DefaultImportName guesses
+func ImportName(file *ast.File, importPath string) string {
+	for _, spec := range file.Imports {
+		specPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || specPath != importPath {
+			continue
+		}
+
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+
+		return DefaultImportName(importPath)
+	}
+
+	return ""
+}
+
+// For get the function name of a wire.Xxx call, empty when it is not a call of the wire import
+func WireFuncName(call *ast.CallExpr, wireName string) string {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+
+	pkgIdent, ok := selector.X.(*ast.Ident)
+	if !ok || wireName == "" || pkgIdent.Name != wireName {
+		return ""
+	}
+
+	return selector.Sel.Name
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWireFuncName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		content          string
+		expectedWireName string
+		expectedFuncs    []string
+	}{
+		{
+			name:             "Default name",
+			content:          "package wire\n\nimport \"github.com/google/wire\"\n\nvar Set = wire.NewSet(wire.Struct(new(S)), fmt.Sprint())\n",
+			expectedWireName: "wire",
+			expectedFuncs:    []string{"NewSet", "Struct", "", ""},
+		},
+		{
+			name:             "Alias",
+			content:          "package wire\n\nimport gw \"github.com/google/wire\"\n\nvar Set = gw.NewSet(wire.Struct())\n",
+			expectedWireName: "gw",
+			expectedFuncs:    []string{"NewSet", ""},
+		},
+		{
+			name:             "Not imported",
+			content:          "package wire\n\nvar Set = wire.NewSet()\n",
+			expectedWireName: "",
+			expectedFuncs:    []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			file, err := parser.ParseFile(token.NewFileSet(), "wire.go", tc.content, parser.SkipObjectResolution)
+			assert.NoError(tt, err)
+
+			wireName := ImportName(file, WireImportPath)
+			assert.Equal(tt, tc.expectedWireName, wireName)
+
+			funcNames := make([]string, 0)
+			ast.Inspect(file, func(node ast.Node) bool {
+				if call, ok := node.(*ast.CallExpr); ok {
+					funcNames = append(funcNames, WireFuncName(call, wireName))
+				}
+
+				return true
+			})
+			assert.Equal(tt, tc.expectedFuncs, funcNames)
+		})
+	}
+}
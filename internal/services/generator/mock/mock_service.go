@@ -2,7 +2,13 @@
 
 package mock_generator
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
 
 // Service is an autogenerated mock type for the Service type
 type Service struct {
@@ -17,17 +23,17 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
-// GenerateWireSet provides a mock function with given fields: verbose
-func (_m *Service) GenerateWireSet(verbose bool) error {
-	ret := _m.Called(verbose)
+// CleanCache provides a mock function with no fields
+func (_m *Service) CleanCache() error {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateWireSet")
+		panic("no return value specified for CleanCache")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(bool) error); ok {
-		r0 = rf(verbose)
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -35,30 +41,443 @@ func (_m *Service) GenerateWireSet(verbose bool) error {
 	return r0
 }
 
+// Service_CleanCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanCache'
+type Service_CleanCache_Call struct {
+	*mock.Call
+}
+
+// CleanCache is a helper method to define mock.On call
+func (_e *Service_Expecter) CleanCache() *Service_CleanCache_Call {
+	return &Service_CleanCache_Call{Call: _e.mock.On("CleanCache")}
+}
+
+func (_c *Service_CleanCache_Call) Run(run func()) *Service_CleanCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Service_CleanCache_Call) Return(_a0 error) *Service_CleanCache_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_CleanCache_Call) RunAndReturn(run func() error) *Service_CleanCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateWireSet provides a mock function with given fields: ctx, options
+func (_m *Service) GenerateWireSet(ctx context.Context, options *models.GenerateOptions) (*models.GenerateResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateWireSet")
+	}
+
+	var r0 *models.GenerateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) (*models.GenerateResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) *models.GenerateResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GenerateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GenerateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Service_GenerateWireSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateWireSet'
 type Service_GenerateWireSet_Call struct {
 	*mock.Call
 }
 
 // GenerateWireSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.GenerateOptions
+func (_e *Service_Expecter) GenerateWireSet(ctx interface{}, options interface{}) *Service_GenerateWireSet_Call {
+	return &Service_GenerateWireSet_Call{Call: _e.mock.On("GenerateWireSet", ctx, options)}
+}
+
+func (_c *Service_GenerateWireSet_Call) Run(run func(ctx context.Context, options *models.GenerateOptions)) *Service_GenerateWireSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.GenerateOptions))
+	})
+	return _c
+}
+
+func (_c *Service_GenerateWireSet_Call) Return(_a0 *models.GenerateResult, _a1 error) *Service_GenerateWireSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_GenerateWireSet_Call) RunAndReturn(run func(context.Context, *models.GenerateOptions) (*models.GenerateResult, error)) *Service_GenerateWireSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGoFiles provides a mock function with given fields: ctx, jobs
+func (_m *Service) ListGoFiles(ctx context.Context, jobs int) ([]string, error) {
+	ret := _m.Called(ctx, jobs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGoFiles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, jobs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, jobs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ListGoFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGoFiles'
+type Service_ListGoFiles_Call struct {
+	*mock.Call
+}
+
+// ListGoFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobs int
+func (_e *Service_Expecter) ListGoFiles(ctx interface{}, jobs interface{}) *Service_ListGoFiles_Call {
+	return &Service_ListGoFiles_Call{Call: _e.mock.On("ListGoFiles", ctx, jobs)}
+}
+
+func (_c *Service_ListGoFiles_Call) Run(run func(ctx context.Context, jobs int)) *Service_ListGoFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Service_ListGoFiles_Call) Return(_a0 []string, _a1 error) *Service_ListGoFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ListGoFiles_Call) RunAndReturn(run func(context.Context, int) ([]string, error)) *Service_ListGoFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadConfig provides a mock function with no fields
+func (_m *Service) LoadConfig() (*models.Config, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadConfig")
+	}
+
+	var r0 *models.Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*models.Config, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *models.Config); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Config)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_LoadConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadConfig'
+type Service_LoadConfig_Call struct {
+	*mock.Call
+}
+
+// LoadConfig is a helper method to define mock.On call
+func (_e *Service_Expecter) LoadConfig() *Service_LoadConfig_Call {
+	return &Service_LoadConfig_Call{Call: _e.mock.On("LoadConfig")}
+}
+
+func (_c *Service_LoadConfig_Call) Run(run func()) *Service_LoadConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Service_LoadConfig_Call) Return(_a0 *models.Config, _a1 error) *Service_LoadConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_LoadConfig_Call) RunAndReturn(run func() (*models.Config, error)) *Service_LoadConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderWireSets provides a mock function with given fields: scan, wireGenLocations, options
+func (_m *Service) RenderWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+	ret := _m.Called(scan, wireGenLocations, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderWireSets")
+	}
+
+	var r0 []*models.GeneratedFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) ([]*models.GeneratedFile, error)); ok {
+		return rf(scan, wireGenLocations, options)
+	}
+	if rf, ok := ret.Get(0).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) []*models.GeneratedFile); ok {
+		r0 = rf(scan, wireGenLocations, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GeneratedFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) error); ok {
+		r1 = rf(scan, wireGenLocations, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_RenderWireSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderWireSets'
+type Service_RenderWireSets_Call struct {
+	*mock.Call
+}
+
+// RenderWireSets is a helper method to define mock.On call
+//   - scan *models.ProjectScan
+//   - wireGenLocations []*models.WireGenLocation
+//   - options *models.GenerateOptions
+func (_e *Service_Expecter) RenderWireSets(scan interface{}, wireGenLocations interface{}, options interface{}) *Service_RenderWireSets_Call {
+	return &Service_RenderWireSets_Call{Call: _e.mock.On("RenderWireSets", scan, wireGenLocations, options)}
+}
+
+func (_c *Service_RenderWireSets_Call) Run(run func(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions)) *Service_RenderWireSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProjectScan), args[1].([]*models.WireGenLocation), args[2].(*models.GenerateOptions))
+	})
+	return _c
+}
+
+func (_c *Service_RenderWireSets_Call) Return(_a0 []*models.GeneratedFile, _a1 error) *Service_RenderWireSets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_RenderWireSets_Call) RunAndReturn(run func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) ([]*models.GeneratedFile, error)) *Service_RenderWireSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanFile provides a mock function with given fields: moduleName, filePath, verbose
+func (_m *Service) ScanFile(moduleName string, filePath string, verbose bool) (*models.FileScan, error) {
+	ret := _m.Called(moduleName, filePath, verbose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanFile")
+	}
+
+	var r0 *models.FileScan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) (*models.FileScan, error)); ok {
+		return rf(moduleName, filePath, verbose)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, bool) *models.FileScan); ok {
+		r0 = rf(moduleName, filePath, verbose)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FileScan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, bool) error); ok {
+		r1 = rf(moduleName, filePath, verbose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ScanFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanFile'
+type Service_ScanFile_Call struct {
+	*mock.Call
+}
+
+// ScanFile is a helper method to define mock.On call
+//   - moduleName string
+//   - filePath string
 //   - verbose bool
-func (_e *Service_Expecter) GenerateWireSet(verbose interface{}) *Service_GenerateWireSet_Call {
-	return &Service_GenerateWireSet_Call{Call: _e.mock.On("GenerateWireSet", verbose)}
+func (_e *Service_Expecter) ScanFile(moduleName interface{}, filePath interface{}, verbose interface{}) *Service_ScanFile_Call {
+	return &Service_ScanFile_Call{Call: _e.mock.On("ScanFile", moduleName, filePath, verbose)}
 }
 
-func (_c *Service_GenerateWireSet_Call) Run(run func(verbose bool)) *Service_GenerateWireSet_Call {
+func (_c *Service_ScanFile_Call) Run(run func(moduleName string, filePath string, verbose bool)) *Service_ScanFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(bool))
+		run(args[0].(string), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Service_GenerateWireSet_Call) Return(_a0 error) *Service_GenerateWireSet_Call {
-	_c.Call.Return(_a0)
+func (_c *Service_ScanFile_Call) Return(_a0 *models.FileScan, _a1 error) *Service_ScanFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ScanFile_Call) RunAndReturn(run func(string, string, bool) (*models.FileScan, error)) *Service_ScanFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanProject provides a mock function with given fields: ctx, options
+func (_m *Service) ScanProject(ctx context.Context, options *models.GenerateOptions) (*models.ProjectScan, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanProject")
+	}
+
+	var r0 *models.ProjectScan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) (*models.ProjectScan, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GenerateOptions) *models.ProjectScan); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectScan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GenerateOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ScanProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanProject'
+type Service_ScanProject_Call struct {
+	*mock.Call
+}
+
+// ScanProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.GenerateOptions
+func (_e *Service_Expecter) ScanProject(ctx interface{}, options interface{}) *Service_ScanProject_Call {
+	return &Service_ScanProject_Call{Call: _e.mock.On("ScanProject", ctx, options)}
+}
+
+func (_c *Service_ScanProject_Call) Run(run func(ctx context.Context, options *models.GenerateOptions)) *Service_ScanProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.GenerateOptions))
+	})
+	return _c
+}
+
+func (_c *Service_ScanProject_Call) Return(_a0 *models.ProjectScan, _a1 error) *Service_ScanProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ScanProject_Call) RunAndReturn(run func(context.Context, *models.GenerateOptions) (*models.ProjectScan, error)) *Service_ScanProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteWireSets provides a mock function with given fields: scan, wireGenLocations, options
+func (_m *Service) WriteWireSets(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+	ret := _m.Called(scan, wireGenLocations, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteWireSets")
+	}
+
+	var r0 []*models.GeneratedFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) ([]*models.GeneratedFile, error)); ok {
+		return rf(scan, wireGenLocations, options)
+	}
+	if rf, ok := ret.Get(0).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) []*models.GeneratedFile); ok {
+		r0 = rf(scan, wireGenLocations, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GeneratedFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) error); ok {
+		r1 = rf(scan, wireGenLocations, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_WriteWireSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteWireSets'
+type Service_WriteWireSets_Call struct {
+	*mock.Call
+}
+
+// WriteWireSets is a helper method to define mock.On call
+//   - scan *models.ProjectScan
+//   - wireGenLocations []*models.WireGenLocation
+//   - options *models.GenerateOptions
+func (_e *Service_Expecter) WriteWireSets(scan interface{}, wireGenLocations interface{}, options interface{}) *Service_WriteWireSets_Call {
+	return &Service_WriteWireSets_Call{Call: _e.mock.On("WriteWireSets", scan, wireGenLocations, options)}
+}
+
+func (_c *Service_WriteWireSets_Call) Run(run func(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions)) *Service_WriteWireSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProjectScan), args[1].([]*models.WireGenLocation), args[2].(*models.GenerateOptions))
+	})
+	return _c
+}
+
+func (_c *Service_WriteWireSets_Call) Return(_a0 []*models.GeneratedFile, _a1 error) *Service_WriteWireSets_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Service_GenerateWireSet_Call) RunAndReturn(run func(bool) error) *Service_GenerateWireSet_Call {
+func (_c *Service_WriteWireSets_Call) RunAndReturn(run func(*models.ProjectScan, []*models.WireGenLocation, *models.GenerateOptions) ([]*models.GeneratedFile, error)) *Service_WriteWireSets_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,9 @@
+package graph
+
+import "errors"
+
+var (
+	ErrUnknownFormat    = errors.New("unknown graph format")
+	ErrSetNotFound      = errors.New("wire set not found")
+	ErrInjectorNotFound = errors.New("injector not found")
+)
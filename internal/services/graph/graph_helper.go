@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+)
+
+type graphNode struct {
+	ID    string
+	Label string
+}
+
+type graphEdge struct {
+	From  string
+	To    string
+	Label string
+}
+
+type graphCluster struct {
+	ID    string
+	Label string
+	Nodes []*graphNode
+}
+
+type providerGraph struct {
+	Clusters []*graphCluster
+	Nodes    []*graphNode
+	Edges    []*graphEdge
+}
+
+// For build the graph where types are nodes, providers are edges and sets are clusters
+// The input must be grouped by set name, a type provided by more than one set is rendered outside the clusters
+func buildGraph(setInfos []*models.WireSetInfo) *providerGraph {
+	result := &providerGraph{}
+	nodes := make(map[string]*graphNode)
+	nodeOrder := make([]string, 0)
+	clustered := make(map[string]bool)
+
+	providingSets := make(map[string]map[string]bool)
+	for _, setInfo := range setInfos {
+		typeName := providerNodeType(setInfo)
+		if providingSets[typeName] == nil {
+			providingSets[typeName] = make(map[string]bool)
+		}
+		providingSets[typeName][setInfo.SetName] = true
+	}
+
+	getNode := func(typeName string) *graphNode {
+		if node, exists := nodes[typeName]; exists {
+			return node
+		}
+
+		node := &graphNode{
+			ID:    fmt.Sprintf("n%d", len(nodes)),
+			Label: typeName,
+		}
+		nodes[typeName] = node
+		nodeOrder = append(nodeOrder, typeName)
+
+		return node
+	}
+
+	// The providers of a cluster whose type is rendered outside the clusters
+	sharedEdges := make(map[*graphCluster][]*graphEdge)
+
+	var currentCluster *graphCluster
+	for _, setInfo := range setInfos {
+		if currentCluster == nil || currentCluster.Label != setInfo.SetName {
+			currentCluster = &graphCluster{
+				ID:    fmt.Sprintf("cluster_%d", len(result.Clusters)),
+				Label: setInfo.SetName,
+			}
+			result.Clusters = append(result.Clusters, currentCluster)
+		}
+
+		providedType := providerNodeType(setInfo)
+		providedNode := getNode(providedType)
+		if !clustered[providedType] && len(providingSets[providedType]) == 1 {
+			clustered[providedType] = true
+			currentCluster.Nodes = append(currentCluster.Nodes, providedNode)
+		} else if len(providingSets[providedType]) > 1 {
+			sharedEdges[currentCluster] = append(sharedEdges[currentCluster], &graphEdge{
+				To:    providedNode.ID,
+				Label: path.Base(setInfo.ImportPath) + "." + setInfo.FunctionName,
+			})
+		}
+
+		for _, paramType := range setInfo.ParamTypes {
+			result.Edges = append(result.Edges, &graphEdge{
+				From:  getNode(paramType).ID,
+				To:    providedNode.ID,
+				Label: path.Base(setInfo.ImportPath) + "." + setInfo.FunctionName,
+			})
+		}
+	}
+
+	// Sets whose types are all shared get a node of their own that points to the shared types,
+	// so the set still shows up in the graph
+	for i, cluster := range result.Clusters {
+		if len(cluster.Nodes) > 0 {
+			continue
+		}
+
+		setNode := &graphNode{
+			ID:    fmt.Sprintf("s%d", i),
+			Label: cluster.Label,
+		}
+		cluster.Nodes = append(cluster.Nodes, setNode)
+		for _, edge := range sharedEdges[cluster] {
+			edge.From = setNode.ID
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+
+	// Types that are not provided by exactly one set are rendered outside the clusters
+	for _, typeName := range nodeOrder {
+		if !clustered[typeName] {
+			result.Nodes = append(result.Nodes, nodes[typeName])
+		}
+	}
+
+	return result
+}
+
+// For get the node of the type a provider provides,
+// providers without readable signature still show up by its function name
+func providerNodeType(setInfo *models.WireSetInfo) string {
+	if providedType := providedType(setInfo.ResultTypes); providedType != "" {
+		return providedType
+	}
+
+	return setInfo.ImportPath + "." + setInfo.FunctionName
+}
+
+// For pick the type a provider provides, cleanup functions and errors are not provided types
+func providedType(resultTypes []string) string {
+	for _, resultType := range resultTypes {
+		if resultType != "error" && resultType != "func()" {
+			return resultType
+		}
+	}
+
+	return ""
+}
+
+func renderDOT(graph *providerGraph) string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph wiresetgen {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	builder.WriteString("\tnode [shape=box];\n")
+
+	for _, cluster := range graph.Clusters {
+		builder.WriteString("\n")
+		fmt.Fprintf(&builder, "\tsubgraph %s {\n", cluster.ID)
+		fmt.Fprintf(&builder, "\t\tlabel=%s;\n", strconv.Quote(cluster.Label))
+		for _, node := range cluster.Nodes {
+			fmt.Fprintf(&builder, "\t\t%s [label=%s];\n", node.ID, strconv.Quote(node.Label))
+		}
+		builder.WriteString("\t}\n")
+	}
+
+	if len(graph.Nodes) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&builder, "\t%s [label=%s];\n", node.ID, strconv.Quote(node.Label))
+	}
+
+	if len(graph.Edges) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&builder, "\t%s -> %s [label=%s];\n", edge.From, edge.To, strconv.Quote(edge.Label))
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func renderMermaid(graph *providerGraph) string {
+	var builder strings.Builder
+
+	builder.WriteString("flowchart LR\n")
+
+	for _, cluster := range graph.Clusters {
+		fmt.Fprintf(&builder, "    subgraph %s [%s]\n", cluster.ID, mermaidLabel(cluster.Label))
+		for _, node := range cluster.Nodes {
+			fmt.Fprintf(&builder, "        %s[%s]\n", node.ID, mermaidLabel(node.Label))
+		}
+		builder.WriteString("    end\n")
+	}
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&builder, "    %s[%s]\n", node.ID, mermaidLabel(node.Label))
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&builder, "    %s -->|%s| %s\n", edge.From, mermaidLabel(edge.Label), edge.To)
+	}
+
+	return builder.String()
+}
+
+// For quote the mermaid label, mermaid uses entity codes instead of backslash escapes
+func mermaidLabel(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
+
+// For extract the identifiers passed to wire.Build inside the injector function
+// Return found as false when the file has no function with that name
+func extractInjectorBuildArgs(filePath string, fileContent string, injectorName string) (args []string, found bool, err error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, fileContent, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, false, err
+	}
+
+	wireName := generator.ImportName(file, generator.WireImportPath)
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || funcDecl.Name.Name != injectorName || funcDecl.Body == nil {
+			continue
+		}
+
+		args = make([]string, 0)
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || generator.WireFuncName(call, wireName) != "Build" {
+				return true
+			}
+
+			for _, arg := range call.Args {
+				switch a := arg.(type) {
+				case *ast.Ident:
+					args = append(args, a.Name)
+				case *ast.SelectorExpr:
+					args = append(args, a.Sel.Name)
+				}
+			}
+
+			return false
+		})
+
+		return args, true, nil
+	}
+
+	return nil, false, nil
+}
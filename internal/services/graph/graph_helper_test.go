@@ -0,0 +1,196 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_renderDOT(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		setInfos []*models.WireSetInfo
+		expected string
+	}{
+		{
+			name:     "Empty graph",
+			setInfos: []*models.WireSetInfo{},
+			expected: "digraph wiresetgen {\n\trankdir=LR;\n\tnode [shape=box];\n}\n",
+		},
+		{
+			name: "Providers in sets",
+			setInfos: []*models.WireSetInfo{
+				{
+					SetName:      "Repository",
+					FunctionName: "NewRepository",
+					ImportPath:   "github.com/foo/bar/repo",
+					ParamTypes:   []string{"*database/sql.DB"},
+					ResultTypes:  []string{"github.com/foo/bar/repo.Repository"},
+				},
+				{
+					SetName:      "Service",
+					FunctionName: "NewService",
+					ImportPath:   "github.com/foo/bar/service",
+					ParamTypes:   []string{"github.com/foo/bar/repo.Repository"},
+					ResultTypes:  []string{"*github.com/foo/bar/service.Service", "func()", "error"},
+				},
+			},
+			expected: `digraph wiresetgen {
+	rankdir=LR;
+	node [shape=box];
+
+	subgraph cluster_0 {
+		label="Repository";
+		n0 [label="github.com/foo/bar/repo.Repository"];
+	}
+
+	subgraph cluster_1 {
+		label="Service";
+		n2 [label="*github.com/foo/bar/service.Service"];
+	}
+
+	n1 [label="*database/sql.DB"];
+
+	n1 -> n0 [label="repo.NewRepository"];
+	n0 -> n2 [label="service.NewService"];
+}
+`,
+		},
+		{
+			name: "Type provided by two sets",
+			setInfos: []*models.WireSetInfo{
+				{
+					SetName:      "Api",
+					FunctionName: "NewApiLogger",
+					ImportPath:   "github.com/foo/bar/api",
+					ResultTypes:  []string{"*log/slog.Logger"},
+				},
+				{
+					SetName:      "Api",
+					FunctionName: "NewHandler",
+					ImportPath:   "github.com/foo/bar/api",
+					ParamTypes:   []string{"*log/slog.Logger"},
+					ResultTypes:  []string{"*github.com/foo/bar/api.Handler"},
+				},
+				{
+					SetName:      "Worker",
+					FunctionName: "NewWorkerLogger",
+					ImportPath:   "github.com/foo/bar/worker",
+					ResultTypes:  []string{"*log/slog.Logger"},
+				},
+			},
+			expected: `digraph wiresetgen {
+	rankdir=LR;
+	node [shape=box];
+
+	subgraph cluster_0 {
+		label="Api";
+		n1 [label="*github.com/foo/bar/api.Handler"];
+	}
+
+	subgraph cluster_1 {
+		label="Worker";
+		s1 [label="Worker"];
+	}
+
+	n0 [label="*log/slog.Logger"];
+
+	n0 -> n1 [label="api.NewHandler"];
+	s1 -> n0 [label="worker.NewWorkerLogger"];
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expected, renderDOT(buildGraph(tc.setInfos)))
+		})
+	}
+}
+
+func Test_renderMermaid(t *testing.T) {
+	t.Parallel()
+
+	setInfos := []*models.WireSetInfo{
+		{
+			SetName:      "Service",
+			FunctionName: "NewService",
+			ImportPath:   "github.com/foo/bar/service",
+			ParamTypes:   []string{"context.Context"},
+			ResultTypes:  []string{"*github.com/foo/bar/service.Service"},
+		},
+		{
+			SetName:      "Service",
+			FunctionName: "NewHandler",
+			ImportPath:   "github.com/foo/bar/service",
+		},
+	}
+
+	expected := `flowchart LR
+    subgraph cluster_0 ["Service"]
+        n0["*github.com/foo/bar/service.Service"]
+        n2["github.com/foo/bar/service.NewHandler"]
+    end
+    n1["context.Context"]
+    n1 -->|"service.NewService"| n0
+`
+
+	assert.Equal(t, expected, renderMermaid(buildGraph(setInfos)))
+}
+
+func Test_extractInjectorBuildArgs(t *testing.T) {
+	t.Parallel()
+
+	fileContent := `//go:build wireinject
+
+package wire
+
+import (
+	"github.com/foo/bar/app"
+	gw "github.com/google/wire"
+)
+
+func InitializeApp() (*app.App, error) {
+	gw.Build(RepositorySet, ServiceSet, app.NewApp)
+	return nil, nil
+}
+`
+
+	testCases := []struct {
+		name          string
+		injectorName  string
+		expectedArgs  []string
+		expectedFound bool
+	}{
+		{
+			name:          "Injector found",
+			injectorName:  "InitializeApp",
+			expectedArgs:  []string{"RepositorySet", "ServiceSet", "NewApp"},
+			expectedFound: true,
+		},
+		{
+			name:          "Injector not found",
+			injectorName:  "InitializeWorker",
+			expectedArgs:  nil,
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			args, found, err := extractInjectorBuildArgs("wire/wire.go", fileContent, tc.injectorName)
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.expectedArgs, args)
+			assert.Equal(tt, tc.expectedFound, found)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+type Service interface {
+	ExportGraph(ctx context.Context, options *models.GraphOptions) (string, error)
+}
+
+type graphServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewGraphService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &graphServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+func (g *graphServiceImpl) ExportGraph(ctx context.Context, options *models.GraphOptions) (string, error) {
+	if options.Format != FormatDOT && options.Format != FormatMermaid {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, options.Format)
+	}
+
+	scan, err := g.generatorService.ScanProject(ctx, &models.GenerateOptions{
+		Verbose: options.Verbose,
+		NoCache: options.NoCache,
+		Jobs:    options.Jobs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Collect the sets that should be in the graph, nil means every set
+	var selectedSets map[string]bool
+	if options.SetName != "" {
+		selectedSets = map[string]bool{options.SetName: true}
+	}
+
+	if options.InjectorName != "" {
+		injectorSets, err := g.findInjectorSets(scan, options.InjectorName)
+		if err != nil {
+			return "", err
+		}
+
+		if options.Verbose {
+			logrus.Infof("Injector %s uses sets %v\n", options.InjectorName, injectorSets)
+		}
+
+		selectedSets = filterSets(selectedSets, injectorSets)
+	}
+
+	setInfos := make([]*models.WireSetInfo, 0, len(scan.SetInfos))
+	for _, setInfo := range scan.SetInfos {
+		if selectedSets == nil || selectedSets[setInfo.SetName] {
+			setInfos = append(setInfos, setInfo)
+		}
+	}
+
+	if len(setInfos) == 0 && options.SetName != "" {
+		return "", fmt.Errorf("%w: %s", ErrSetNotFound, options.SetName)
+	}
+
+	// An injector that only builds sets the scan does not know would render an empty graph
+	if len(setInfos) == 0 && options.InjectorName != "" {
+		return "", fmt.Errorf("%w: no set of injector %s", ErrSetNotFound, options.InjectorName)
+	}
+
+	// Group providers by set name so clusters are rendered in a stable order
+	sort.SliceStable(setInfos, func(i, j int) bool {
+		return setInfos[i].SetName < setInfos[j].SetName
+	})
+
+	providerGraph := buildGraph(setInfos)
+
+	if options.Format == FormatMermaid {
+		return renderMermaid(providerGraph), nil
+	}
+
+	return renderDOT(providerGraph), nil
+}
+
+// For find the sets passed to wire.Build in the injector function
+func (g *graphServiceImpl) findInjectorSets(scan *models.ProjectScan, injectorName string) ([]string, error) {
+	config, err := g.generatorService.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	setNames := make(map[string]bool)
+	for _, setInfo := range scan.SetInfos {
+		setNames[setInfo.SetName] = true
+	}
+
+	for _, location := range scan.WireGenLocations {
+		fileContent, err := g.fileRepository.ReadFile(location.FilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		buildArgs, found, err := extractInjectorBuildArgs(location.FilePath, fileContent, injectorName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		injectorSets := make([]string, 0, len(buildArgs))
+		for _, arg := range buildArgs {
+			for setName := range setNames {
+				if arg == generator.SetVarName(setName, &config.Naming) {
+					injectorSets = append(injectorSets, setName)
+				}
+			}
+		}
+		sort.Strings(injectorSets)
+
+		return injectorSets, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInjectorNotFound, injectorName)
+}
+
+func filterSets(selectedSets map[string]bool, injectorSets []string) map[string]bool {
+	filtered := make(map[string]bool)
+	for _, setName := range injectorSets {
+		if selectedSets == nil || selectedSets[setName] {
+			filtered[setName] = true
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	mock_generator "github.com/graphzc/wiresetgen/internal/services/generator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportGraph_injector(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		injector         string
+		setName          string
+		expectedContents []string
+		expectedError    error
+	}{
+		{
+			name:             "Known set",
+			injector:         "wire.Build(ServiceSet)",
+			expectedContents: []string{"label=\"Service\";"},
+		},
+		{
+			name:          "Unknown set",
+			injector:      "wire.Build(RepositorySet)",
+			expectedError: ErrSetNotFound,
+		},
+		{
+			name:          "Set not built by the injector",
+			injector:      "wire.Build(ServiceSet)",
+			setName:       "Repository",
+			expectedError: ErrSetNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			fileRepository := mock_files.NewRepository(tt)
+			fileRepository.EXPECT().ReadFile("wire/wire.go").Return("//go:build wireinject\n\npackage wire\n\n"+
+				"import \"github.com/google/wire\"\n\nfunc InitializeApp() *App {\n\t"+tc.injector+"\n\treturn nil\n}\n", nil)
+
+			generatorService := mock_generator.NewService(tt)
+			generatorService.EXPECT().ScanProject(mock.Anything, mock.Anything).Return(&models.ProjectScan{
+				SetInfos: []*models.WireSetInfo{
+					{
+						SetName:      "Service",
+						FunctionName: "NewService",
+						ImportPath:   "github.com/foo/bar/service",
+						ResultTypes:  []string{"*github.com/foo/bar/service.Service"},
+					},
+				},
+				WireGenLocations: []*models.WireGenLocation{{PackageName: "wire", DirectoryPath: "wire", FilePath: "wire/wire.go"}},
+			}, nil)
+			generatorService.EXPECT().LoadConfig().Return(&models.Config{}, nil)
+
+			service := NewGraphService(fileRepository, generatorService)
+
+			result, err := service.ExportGraph(context.Background(), &models.GraphOptions{
+				Format:       FormatDOT,
+				SetName:      tc.setName,
+				InjectorName: "InitializeApp",
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(tt, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(tt, err)
+			for _, expectedContent := range tc.expectedContents {
+				assert.Contains(tt, result, expectedContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_graph
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+type Service_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Service) EXPECT() *Service_Expecter {
+	return &Service_Expecter{mock: &_m.Mock}
+}
+
+// ExportGraph provides a mock function with given fields: ctx, options
+func (_m *Service) ExportGraph(ctx context.Context, options *models.GraphOptions) (string, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportGraph")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GraphOptions) (string, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GraphOptions) string); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GraphOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ExportGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportGraph'
+type Service_ExportGraph_Call struct {
+	*mock.Call
+}
+
+// ExportGraph is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.GraphOptions
+func (_e *Service_Expecter) ExportGraph(ctx interface{}, options interface{}) *Service_ExportGraph_Call {
+	return &Service_ExportGraph_Call{Call: _e.mock.On("ExportGraph", ctx, options)}
+}
+
+func (_c *Service_ExportGraph_Call) Run(run func(ctx context.Context, options *models.GraphOptions)) *Service_ExportGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.GraphOptions))
+	})
+	return _c
+}
+
+func (_c *Service_ExportGraph_Call) Return(_a0 string, _a1 error) *Service_ExportGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ExportGraph_Call) RunAndReturn(run func(context.Context, *models.GraphOptions) (string, error)) *Service_ExportGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
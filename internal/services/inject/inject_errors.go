@@ -0,0 +1,16 @@
+package inject
+
+import "errors"
+
+var (
+	ErrInvalidType         = errors.New("invalid type")
+	ErrInvalidInjectorName = errors.New("invalid injector name")
+	ErrNoSets              = errors.New("no sets given")
+	ErrSetNotFound         = errors.New("set not found")
+	ErrTypeNotProvided     = errors.New("type is not provided by the sets")
+	ErrAmbiguousType       = errors.New("type matches several provided types")
+	ErrUnsupportedType     = errors.New("type cannot be written in the injector")
+	ErrLocationNotFound    = errors.New("wireinject file not found")
+	ErrAmbiguousLocation   = errors.New("several wireinject files found, choose one with --dir")
+	ErrInjectorExists      = errors.New("injector already declared")
+)
@@ -0,0 +1,101 @@
+package inject
+
+import (
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+)
+
+// The type given on the command line, e.g. *service.Service or example.com/app/service.Service
+type typeRef struct {
+	pointer     bool
+	packageName string
+	name        string
+}
+
+func parseTypeRef(value string) (*typeRef, bool) {
+	ref := &typeRef{}
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "*") {
+		ref.pointer = true
+		value = strings.TrimPrefix(value, "*")
+	}
+
+	if index := strings.LastIndex(value, "."); index >= 0 {
+		ref.packageName = value[:index]
+		value = value[index+1:]
+	}
+
+	ref.name = value
+	if !token.IsIdentifier(ref.name) || strings.HasPrefix(ref.packageName, "*") {
+		return nil, false
+	}
+
+	return ref, true
+}
+
+func (r *typeRef) String() string {
+	value := r.name
+	if r.packageName != "" {
+		value = r.packageName + "." + value
+	}
+	if r.pointer {
+		value = "*" + value
+	}
+
+	return value
+}
+
+// For check if a scanned type is the referenced type
+// A reference without the star matches both the value and the pointer
+func (r *typeRef) matches(scannedType string, localImportPath string) bool {
+	pointer := strings.HasPrefix(scannedType, "*")
+	if r.pointer && !pointer {
+		return false
+	}
+
+	index := strings.LastIndex(scannedType, ".")
+	if index < 0 {
+		return false
+	}
+
+	importPath := strings.TrimPrefix(scannedType[:index], "*")
+	if scannedType[index+1:] != r.name {
+		return false
+	}
+
+	switch r.packageName {
+	case "":
+		return importPath == localImportPath
+	case importPath, generator.DefaultImportName(importPath):
+		return true
+	default:
+		return false
+	}
+}
+
+// For pick an import name that is not used yet in the file
+func importName(importPath string, usedNames map[string]bool) string {
+	name := generator.DefaultImportName(importPath)
+	if !token.IsIdentifier(name) {
+		name = strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, path.Base(importPath))
+	}
+
+	candidate := name
+	for i := 2; usedNames[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	usedNames[candidate] = true
+
+	return candidate
+}
@@ -0,0 +1,156 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const localImportPath = "github.com/foo/bar/app"
+
+var testSetInfos = []*models.WireSetInfo{
+	{
+		SetName:      "Repository",
+		FunctionName: "NewRepository",
+		ImportPath:   "github.com/foo/bar/repo",
+		ParamTypes:   []string{"*database/sql.DB"},
+		ResultTypes:  []string{"*github.com/foo/bar/repo.Repository"},
+	},
+	{
+		SetName:      "Service",
+		FunctionName: "NewService",
+		ImportPath:   "github.com/foo/bar/service",
+		ParamTypes:   []string{"*github.com/foo/bar/repo.Repository", "*database/sql.DB", "github.com/foo/bar/config.Config"},
+		ResultTypes:  []string{"*github.com/foo/bar/service.Service", "func()", "error"},
+	},
+	{
+		SetName:      "App",
+		FunctionName: "NewApp",
+		ImportPath:   localImportPath,
+		ParamTypes:   []string{"*github.com/foo/bar/service.Service"},
+		ResultTypes:  []string{"*github.com/foo/bar/app.App"},
+	},
+}
+
+func Test_parseTypeRef(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected *typeRef
+	}{
+		{
+			name:     "Local type",
+			value:    "App",
+			expected: &typeRef{name: "App"},
+		},
+		{
+			name:     "Pointer to package type",
+			value:    "*service.Service",
+			expected: &typeRef{pointer: true, packageName: "service", name: "Service"},
+		},
+		{
+			name:     "Import path",
+			value:    "github.com/foo/bar/service.Service",
+			expected: &typeRef{packageName: "github.com/foo/bar/service", name: "Service"},
+		},
+		{
+			name:     "Missing name",
+			value:    "service.",
+			expected: nil,
+		},
+		{
+			name:     "Star in the middle",
+			value:    "service.*Service",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, ok := parseTypeRef(tt.value)
+
+			assert.Equal(t, tt.expected != nil, ok)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func Test_typeRef_matches(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		value       string
+		scannedType string
+		expected    bool
+	}{
+		{
+			name:        "Package name matches a pointer",
+			value:       "service.Service",
+			scannedType: "*github.com/foo/bar/service.Service",
+			expected:    true,
+		},
+		{
+			name:        "Pointer does not match a value",
+			value:       "*service.Service",
+			scannedType: "github.com/foo/bar/service.Service",
+			expected:    false,
+		},
+		{
+			name:        "Import path",
+			value:       "*github.com/foo/bar/service.Service",
+			scannedType: "*github.com/foo/bar/service.Service",
+			expected:    true,
+		},
+		{
+			name:        "Other package",
+			value:       "repo.Service",
+			scannedType: "*github.com/foo/bar/service.Service",
+			expected:    false,
+		},
+		{
+			name:        "Local type",
+			value:       "App",
+			scannedType: "*github.com/foo/bar/app.App",
+			expected:    true,
+		},
+		{
+			name:        "Unqualified type of another package",
+			value:       "Service",
+			scannedType: "*github.com/foo/bar/service.Service",
+			expected:    false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, ok := parseTypeRef(tt.value)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, ref.matches(tt.scannedType, localImportPath))
+		})
+	}
+}
+
+func Test_selectSets(t *testing.T) {
+	t.Parallel()
+
+	varNames, setInfos, err := selectSets(testSetInfos, []string{"AppSet", "Repository", "RepositorySet"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AppSet", "RepositorySet"}, varNames)
+	assert.Equal(t, []*models.WireSetInfo{testSetInfos[0], testSetInfos[2]}, setInfos)
+
+	_, _, err = selectSets(testSetInfos, []string{"MissingSet"}, nil)
+	assert.ErrorIs(t, err, ErrSetNotFound)
+
+	_, _, err = selectSets(testSetInfos, nil, nil)
+	assert.ErrorIs(t, err, ErrNoSets)
+}
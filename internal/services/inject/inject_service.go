@@ -0,0 +1,314 @@
+package inject
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/graphzc/wiresetgen/internal/templates"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+type Service interface {
+	GenerateInjector(ctx context.Context, options *models.InjectOptions) (*models.GeneratedFile, error)
+}
+
+type injectServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewInjectService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &injectServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+func (i *injectServiceImpl) GenerateInjector(ctx context.Context, options *models.InjectOptions) (*models.GeneratedFile, error) {
+	ref, ok := parseTypeRef(options.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidType, options.Type)
+	}
+
+	scan, err := i.generatorService.ScanProject(ctx, &models.GenerateOptions{
+		Verbose: options.Verbose,
+		NoCache: options.NoCache,
+		Jobs:    options.Jobs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := findLocation(scan.WireGenLocations, options.Directory)
+	if err != nil {
+		return nil, err
+	}
+	localImportPath := path.Join(scan.ModuleName, filepath.ToSlash(location.DirectoryPath))
+
+	config, err := i.generatorService.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	wireSets, setInfos, err := selectSets(scan.SetInfos, options.Sets, &config.Naming)
+	if err != nil {
+		return nil, err
+	}
+
+	resultType, err := findResultType(setInfos, ref, localImportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	injectorName := options.Name
+	if injectorName == "" {
+		injectorName = "Initialize" + ref.name
+	}
+	if !token.IsIdentifier(injectorName) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInjectorName, injectorName)
+	}
+
+	// Providers with a cleanup or an error make wire require them from the injector too
+	resultTypes := []string{resultType}
+	if generator.HasResult(setInfos, "func()") {
+		resultTypes = append(resultTypes, "func()")
+	}
+	if generator.HasResult(setInfos, "error") {
+		resultTypes = append(resultTypes, "error")
+	}
+	paramTypes := generator.UnprovidedTypes(setInfos)
+
+	for _, scannedType := range append(append([]string{}, resultTypes...), paramTypes...) {
+		if strings.Contains(scannedType, "{...}") {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, scannedType)
+		}
+	}
+
+	content, err := i.fileRepository.ReadFile(location.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, location.FilePath, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDeclared(file, injectorName) {
+		return nil, fmt.Errorf("%w: %s in %s", ErrInjectorExists, injectorName, location.FilePath)
+	}
+
+	importPaths := append([]string{generator.WireImportPath}, generator.TypeImportPaths(append(append([]string{}, resultTypes...), paramTypes...))...)
+	importNames := addImports(fset, file, importPaths, localImportPath)
+
+	model := &models.InjectorTemplateModel{
+		Name:     injectorName,
+		TypeName: generator.SourceType(resultType, localImportPath, importNames),
+		WireName: importNames[generator.WireImportPath],
+		Params:   make([]*models.InjectorParam, 0, len(paramTypes)),
+		Results:  make([]string, 0, len(resultTypes)),
+		WireSets: wireSets,
+	}
+
+	usedNames := generator.TopLevelNames(file)
+	for _, importName := range importNames {
+		usedNames[importName] = true
+	}
+	for _, paramType := range paramTypes {
+		model.Params = append(model.Params, &models.InjectorParam{
+			Name: generator.TypeVarName(paramType, usedNames),
+			Type: generator.SourceType(paramType, localImportPath, importNames),
+		})
+	}
+	for _, resultType := range resultTypes {
+		model.Results = append(model.Results, generator.SourceType(resultType, localImportPath, importNames))
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("injector").Funcs(template.FuncMap{"join": strings.Join}).Parse(templates.InjectorFuncTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(&buf, model); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	generatedFile := &models.GeneratedFile{
+		DirectoryPath: location.DirectoryPath,
+		FileName:      filepath.Base(location.FilePath),
+		Content:       string(formatted),
+	}
+
+	if options.DryRun {
+		return generatedFile, nil
+	}
+
+	if err := i.fileRepository.WriteFile(generatedFile.DirectoryPath, generatedFile.FileName, generatedFile.Content); err != nil {
+		return nil, err
+	}
+
+	if options.Verbose {
+		logrus.Infof("Added injector %s to %s\n", injectorName, location.FilePath)
+	}
+
+	return generatedFile, nil
+}
+
+// For pick the wireinject file, the directory is needed only when the project has several
+func findLocation(locations []*models.WireGenLocation, directory string) (*models.WireGenLocation, error) {
+	if directory != "" {
+		directory = filepath.Clean(directory)
+		for _, location := range locations {
+			if location.DirectoryPath == directory {
+				return location, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, directory)
+	}
+
+	switch len(locations) {
+	case 0:
+		return nil, ErrLocationNotFound
+	case 1:
+		return locations[0], nil
+	default:
+		return nil, ErrAmbiguousLocation
+	}
+}
+
+// For get the generated variable names and the providers of the requested sets
+// A set is given by its variable name, e.g. AppSet, or by its annotation name
+func selectSets(allSetInfos []*models.WireSetInfo, requestedSets []string, naming *models.NamingConfig) ([]string, []*models.WireSetInfo, error) {
+	if len(requestedSets) == 0 {
+		return nil, nil, ErrNoSets
+	}
+
+	varNames := make([]string, 0, len(requestedSets))
+	selected := make(map[string]bool)
+	for _, requestedSet := range requestedSets {
+		found := false
+		for _, setInfo := range allSetInfos {
+			varName := generator.SetVarName(setInfo.SetName, naming)
+			if varName == requestedSet || setInfo.SetName == requestedSet {
+				found = true
+				if !selected[varName] {
+					selected[varName] = true
+					varNames = append(varNames, varName)
+				}
+				break
+			}
+		}
+
+		if !found {
+			return nil, nil, fmt.Errorf("%w: %s", ErrSetNotFound, requestedSet)
+		}
+	}
+
+	setInfos := make([]*models.WireSetInfo, 0)
+	for _, setInfo := range allSetInfos {
+		if selected[generator.SetVarName(setInfo.SetName, naming)] {
+			setInfos = append(setInfos, setInfo)
+		}
+	}
+
+	return varNames, setInfos, nil
+}
+
+func findResultType(setInfos []*models.WireSetInfo, ref *typeRef, localImportPath string) (string, error) {
+	matches := make([]string, 0)
+	for _, setInfo := range setInfos {
+		resultType := generator.ProvidedType(setInfo.ResultTypes)
+		if !ref.matches(resultType, localImportPath) {
+			continue
+		}
+
+		duplicate := false
+		for _, match := range matches {
+			duplicate = duplicate || match == resultType
+		}
+		if !duplicate {
+			matches = append(matches, resultType)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrTypeNotProvided, ref.String())
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%w: %s matches %s", ErrAmbiguousType, ref.String(), strings.Join(matches, ", "))
+	}
+}
+
+// For add the missing imports and get the local name of every import path
+func addImports(fset *token.FileSet, file *ast.File, importPaths []string, localImportPath string) map[string]string {
+	importNames := make(map[string]string)
+	usedNames := generator.TopLevelNames(file)
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := generator.DefaultImportName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+
+		importNames[importPath] = name
+		usedNames[name] = true
+	}
+
+	for _, importPath := range importPaths {
+		if importPath == localImportPath {
+			continue
+		}
+		if _, exists := importNames[importPath]; exists {
+			continue
+		}
+
+		name := importName(importPath, usedNames)
+		if name == generator.DefaultImportName(importPath) {
+			astutil.AddImport(fset, file, importPath)
+		} else {
+			astutil.AddNamedImport(fset, file, name, importPath)
+		}
+		importNames[importPath] = name
+	}
+
+	return importNames
+}
+
+func isDeclared(file *ast.File, name string) bool {
+	return generator.TopLevelNames(file)[name]
+}
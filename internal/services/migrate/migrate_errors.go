@@ -0,0 +1,7 @@
+package migrate
+
+import "errors"
+
+var (
+	ErrCannotParseFile = errors.New("cannot parse file")
+)
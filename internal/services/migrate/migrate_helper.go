@@ -0,0 +1,274 @@
+package migrate
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// A hand-written var X = wire.NewSet(...) declaration
+type providerSet struct {
+	varName string
+	line    int
+	entries []*setEntry
+	imports []*importSpec
+
+	// Byte range of the declaration with its doc comment, whole lines
+	start int
+	end   int
+
+	// Set by the service, a declaration that is not removed is commented out
+	remove bool
+	edit   bool
+}
+
+// An argument of wire.NewSet, reason is set when it cannot become an annotation
+type setEntry struct {
+	line         int
+	expression   string
+	packageName  string
+	functionName string
+	reason       string
+}
+
+type importSpec struct {
+	name string
+	path string
+}
+
+// For find the wire.NewSet declarations of a file
+func findProviderSets(filePath string, content string) ([]*providerSet, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	wireName := generator.ImportName(file, generator.WireImportPath)
+	if wireName == "" {
+		return nil, nil
+	}
+
+	imports := make([]*importSpec, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports = append(imports, &importSpec{name: name, path: importPath})
+	}
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	sets := make([]*providerSet, 0)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || len(valueSpec.Names) != 1 || len(valueSpec.Values) != 1 {
+				continue
+			}
+
+			call, ok := valueSpec.Values[0].(*ast.CallExpr)
+			if !ok || generator.WireFuncName(call, wireName) != "NewSet" {
+				continue
+			}
+
+			// A single declaration is removed with the var keyword, a grouped one only by its spec
+			var start, end token.Pos
+			if len(genDecl.Specs) == 1 {
+				start, end = genDecl.Pos(), genDecl.End()
+				if genDecl.Doc != nil {
+					start = genDecl.Doc.Pos()
+				}
+			} else {
+				start, end = valueSpec.Pos(), valueSpec.End()
+				if valueSpec.Doc != nil {
+					start = valueSpec.Doc.Pos()
+				}
+			}
+
+			set := &providerSet{
+				varName: valueSpec.Names[0].Name,
+				line:    fset.Position(valueSpec.Pos()).Line,
+				imports: imports,
+				start:   lineStart(content, offset(start)),
+				end:     lineEnd(content, offset(end)),
+			}
+
+			for _, arg := range call.Args {
+				entry := &setEntry{
+					line:       fset.Position(arg.Pos()).Line,
+					expression: strings.Join(strings.Fields(content[offset(arg.Pos()):offset(arg.End())]), " "),
+				}
+
+				switch arg := arg.(type) {
+				case *ast.Ident:
+					entry.functionName = arg.Name
+				case *ast.SelectorExpr:
+					if pkgIdent, ok := arg.X.(*ast.Ident); ok {
+						entry.packageName = pkgIdent.Name
+						entry.functionName = arg.Sel.Name
+					} else {
+						entry.reason = "not a package function"
+					}
+				case *ast.CallExpr:
+					if funcName := generator.WireFuncName(arg, wireName); funcName != "" {
+						entry.reason = "wire." + funcName + " cannot be annotated"
+					} else {
+						entry.reason = "call expressions cannot be annotated"
+					}
+				default:
+					entry.reason = "not a package function"
+				}
+
+				set.entries = append(set.entries, entry)
+			}
+
+			sets = append(sets, set)
+		}
+	}
+
+	return sets, nil
+}
+
+// For remove or comment out the declarations marked for edit
+func editDeclarations(content string, sets []*providerSet) string {
+	edited := make([]*providerSet, 0, len(sets))
+	for _, set := range sets {
+		if set.edit {
+			edited = append(edited, set)
+		}
+	}
+
+	// Edit from the end so the offsets of the earlier declarations stay valid
+	sort.Slice(edited, func(i, j int) bool {
+		return edited[i].start > edited[j].start
+	})
+
+	for _, set := range edited {
+		replacement := ""
+		if !set.remove {
+			// Comment at the indentation of the first line, so the nested lines keep their own
+			declaration := content[set.start:set.end]
+			indent := declaration[:len(declaration)-len(strings.TrimLeft(declaration, " \t"))]
+
+			lines := strings.SplitAfter(declaration, "\n")
+			for i := range lines {
+				if strings.TrimSpace(lines[i]) != "" {
+					lines[i] = indent + "// " + strings.TrimPrefix(lines[i], indent)
+				}
+			}
+			replacement = strings.Join(lines, "")
+		}
+
+		content = content[:set.start] + replacement + content[set.end:]
+	}
+
+	return content
+}
+
+// For drop the imports the edit left unused, an import is dropped when the original content
+// used it and the edited content does not, the name of an import without alias comes from packageName
+func removeUnusedImports(filePath string, original string, content string, packageName func(importPath string) (string, error)) (string, error) {
+	originalFile, err := parser.ParseFile(token.NewFileSet(), filePath, original, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	// Deleting an import changes file.Imports, so the specs are copied first
+	removed := false
+	for _, spec := range slices.Clone(file.Imports) {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		alias, name := "", ""
+		if spec.Name != nil {
+			alias = spec.Name.Name
+			name = alias
+		} else if name, err = packageName(importPath); err != nil {
+			return "", err
+		}
+		if name == "_" || name == "." || !usesName(originalFile, name) || usesName(file, name) {
+			continue
+		}
+
+		astutil.DeleteNamedImport(fset, file, alias, importPath)
+		removed = true
+	}
+	if !removed {
+		return content, nil
+	}
+
+	// A single import left in a group is printed without the parentheses
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT && len(genDecl.Specs) == 1 {
+			genDecl.Lparen = token.NoPos
+			genDecl.Rparen = token.NoPos
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// For check if a package name is used by a selector of the file, like astutil.UsesImport does
+func usesName(file *ast.File, name string) bool {
+	used := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if pkgIdent, ok := selector.X.(*ast.Ident); ok && pkgIdent.Name == name && pkgIdent.Obj == nil {
+				used = true
+			}
+		}
+
+		return !used
+	})
+
+	return used
+}
+
+func lineStart(content string, offset int) int {
+	return strings.LastIndex(content[:offset], "\n") + 1
+}
+
+// The end offset includes the line break, so removed lines leave no blank line behind
+func lineEnd(content string, offset int) int {
+	if i := strings.Index(content[offset:], "\n"); i >= 0 {
+		return offset + i + 1
+	}
+
+	return len(content)
+}
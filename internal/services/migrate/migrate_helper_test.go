@@ -0,0 +1,174 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+const serviceFile = `package service
+
+import (
+	"io"
+
+	"github.com/google/wire"
+
+	"example.com/app/repo"
+)
+
+func NewService() *Service { return &Service{} }
+
+// ServiceSet provides the service
+var ServiceSet = wire.NewSet(
+	NewService,
+	repo.NewRepository,
+	wire.Bind(new(io.Reader), new(*File)),
+	wire.Value(1),
+	newOption(),
+)
+`
+
+func Test_findProviderSets(t *testing.T) {
+	t.Parallel()
+
+	sets, err := findProviderSets("service/service.go", serviceFile)
+
+	assert.NoError(t, err)
+	if assert.Len(t, sets, 1) {
+		set := sets[0]
+		assert.Equal(t, "ServiceSet", set.varName)
+		assert.Equal(t, 14, set.line)
+		assert.Equal(t, "// ServiceSet provides the service\n", serviceFile[set.start:set.start+35])
+		assert.Equal(t, len(serviceFile), set.end)
+		assert.Equal(t, []*importSpec{
+			{path: "io"},
+			{path: "github.com/google/wire"},
+			{path: "example.com/app/repo"},
+		}, set.imports)
+		assert.Equal(t, []*setEntry{
+			{line: 15, expression: "NewService", functionName: "NewService"},
+			{line: 16, expression: "repo.NewRepository", packageName: "repo", functionName: "NewRepository"},
+			{line: 17, expression: "wire.Bind(new(io.Reader), new(*File))", reason: "wire.Bind cannot be annotated"},
+			{line: 18, expression: "wire.Value(1)", reason: "wire.Value cannot be annotated"},
+			{line: 19, expression: "newOption()", reason: "call expressions cannot be annotated"},
+		}, set.entries)
+	}
+}
+
+func Test_findProviderSets_withoutWire(t *testing.T) {
+	t.Parallel()
+
+	sets, err := findProviderSets("service/service.go", "package service\n\nvar ServiceSet = NewSet(NewService)\n")
+
+	assert.NoError(t, err)
+	assert.Empty(t, sets)
+}
+
+func Test_editDeclarations(t *testing.T) {
+	t.Parallel()
+
+	content := "package service\n\nimport \"github.com/google/wire\"\n\nvar (\n\tname = \"x\"\n\n\tServiceSet = wire.NewSet(\n\t\tNewService,\n\t)\n)\n\nvar RepoSet = wire.NewSet(NewRepo)\n"
+
+	testCases := []struct {
+		name            string
+		remove          bool
+		expectedContent string
+	}{
+		{
+			name:            "Remove",
+			remove:          true,
+			expectedContent: "package service\n\nimport \"github.com/google/wire\"\n\nvar (\n\tname = \"x\"\n\n)\n\n",
+		},
+		{
+			name:            "Comment out",
+			remove:          false,
+			expectedContent: "package service\n\nimport \"github.com/google/wire\"\n\nvar (\n\tname = \"x\"\n\n\t// ServiceSet = wire.NewSet(\n\t// \tNewService,\n\t// )\n)\n\n// var RepoSet = wire.NewSet(NewRepo)\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			sets, err := findProviderSets("service/service.go", content)
+			assert.NoError(tt, err)
+			assert.Len(tt, sets, 2)
+
+			for _, set := range sets {
+				set.edit = true
+				set.remove = tc.remove
+			}
+
+			assert.Equal(tt, tc.expectedContent, editDeclarations(content, sets))
+		})
+	}
+}
+
+func Test_removeUnusedImports(t *testing.T) {
+	t.Parallel()
+
+	// The package clause of a module package may not match its directory
+	packageNames := map[string]string{"example.com/app/transport/http": "httpapi"}
+
+	testCases := []struct {
+		name            string
+		original        string
+		content         string
+		expectedContent string
+	}{
+		{
+			name:            "Unused imports",
+			original:        "package service\n\nimport (\n\t\"io\"\n\n\t\"github.com/google/wire\"\n\n\t\"example.com/app/repo\"\n\t\"example.com/app/store\"\n)\n\nvar Set = wire.NewSet(repo.New, store.New, wire.Bind(new(io.Reader), new(*File)))\n\nvar s = store.New\n",
+			content:         "package service\n\nimport (\n\t\"io\"\n\n\t\"github.com/google/wire\"\n\n\t\"example.com/app/repo\"\n\t\"example.com/app/store\"\n)\n\nvar s = store.New\n",
+			expectedContent: "package service\n\nimport \"example.com/app/store\"\n\nvar s = store.New\n",
+		},
+		{
+			name:            "Used import",
+			original:        "package service\n\nimport \"github.com/google/wire\"\n\nvar Set = wire.NewSet()\n",
+			content:         "package service\n\nimport \"github.com/google/wire\"\n\nvar Set = wire.NewSet()\n",
+			expectedContent: "package service\n\nimport \"github.com/google/wire\"\n\nvar Set = wire.NewSet()\n",
+		},
+		{
+			name:            "Unused alias",
+			original:        "package service\n\nimport gw \"github.com/google/wire\"\n\nvar Set = gw.NewSet()\n",
+			content:         "package service\n\nimport gw \"github.com/google/wire\"\n\nvar x = 1\n",
+			expectedContent: "package service\n\nvar x = 1\n",
+		},
+		{
+			name:            "Package name is not the last element",
+			original:        "package service\n\nimport \"gopkg.in/yaml.v3\"\n\nvar d = yaml.NewDecoder\n",
+			content:         "package service\n\nimport \"gopkg.in/yaml.v3\"\n\nvar d = yaml.NewDecoder\n",
+			expectedContent: "package service\n\nimport \"gopkg.in/yaml.v3\"\n\nvar d = yaml.NewDecoder\n",
+		},
+		{
+			name:            "Package clause differs from the directory",
+			original:        "package service\n\nimport (\n\t\"net/http\"\n\n\t\"example.com/app/transport/http\"\n)\n\nvar Set = httpapi.NewServer\n\nvar c = http.Client{}\n",
+			content:         "package service\n\nimport (\n\t\"net/http\"\n\n\t\"example.com/app/transport/http\"\n)\n\nvar c = http.Client{}\n",
+			expectedContent: "package service\n\nimport \"net/http\"\n\nvar c = http.Client{}\n",
+		},
+		{
+			name:            "Import used only by a local name",
+			original:        "package service\n\nimport \"example.com/app/repo\"\n\nvar Set = repo.New\n",
+			content:         "package service\n\nimport \"example.com/app/repo\"\n\nfunc f(repo *R) { repo.Close() }\n",
+			expectedContent: "package service\n\nfunc f(repo *R) { repo.Close() }\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			content, err := removeUnusedImports("service/service.go", tc.original, tc.content, func(importPath string) (string, error) {
+				if name, ok := packageNames[importPath]; ok {
+					return name, nil
+				}
+
+				return generator.DefaultImportName(importPath), nil
+			})
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.expectedContent, content)
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/sirupsen/logrus"
+)
+
+// Only files with one of these markers can declare a provider set
+var migrateMarkers = []string{"NewSet("}
+
+// Only files with one of these markers can have an annotation to convert
+var convertMarkers = []string{generator.AnnotationMarker, generator.SetDirective}
+
+type Service interface {
+	MigrateWireSets(ctx context.Context, options *models.MigrateOptions) (*models.MigrateResult, error)
+	ConvertAnnotations(ctx context.Context, options *models.ConvertOptions) (*models.ConvertResult, error)
+}
+
+type migrateServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewMigrateService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &migrateServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+// The top-level functions of a package directory and their annotations
+type packageIndex struct {
+	packageName string
+	functions   map[string]string
+	annotations map[string]string
+}
+
+// The state of one run, files are read once and edited in memory
+type migration struct {
+	service     *migrateServiceImpl
+	moduleName  string
+	naming      *models.NamingConfig
+	style       string
+	directories map[string][]string
+	contents    map[string]string
+	packages    map[string]*packageIndex
+
+	// Planned annotations by file path and function name, and by package function for conflicts
+	annotations      map[string]map[string]string
+	plannedFunctions map[string]string
+}
+
+func (m *migrateServiceImpl) MigrateWireSets(ctx context.Context, options *models.MigrateOptions) (*models.MigrateResult, error) {
+	scan, err := m.generatorService.ScanProject(ctx, &models.GenerateOptions{
+		Verbose: options.Verbose,
+		NoCache: options.NoCache,
+		Jobs:    options.Jobs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	goFiles, err := m.generatorService.ListGoFiles(ctx, options.Jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := m.generatorService.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &migration{
+		service:          m,
+		moduleName:       scan.ModuleName,
+		naming:           &config.Naming,
+		style:            config.Style,
+		directories:      make(map[string][]string),
+		contents:         make(map[string]string),
+		packages:         make(map[string]*packageIndex),
+		annotations:      make(map[string]map[string]string),
+		plannedFunctions: make(map[string]string),
+	}
+	for _, goFile := range goFiles {
+		directory := filepath.Dir(goFile)
+		state.directories[directory] = append(state.directories[directory], goFile)
+	}
+
+	result := &models.MigrateResult{
+		Sets:  make([]*models.MigratedSet, 0),
+		Files: make([]*models.GeneratedFile, 0),
+	}
+	fileSets := make(map[string][]*providerSet)
+	editedFiles := make([]string, 0)
+
+	for _, goFile := range goFiles {
+		sets, err := state.findFileSets(goFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(sets) == 0 {
+			continue
+		}
+
+		for _, set := range sets {
+			migratedSet, err := state.planSet(goFile, set, options.Comment)
+			if err != nil {
+				return nil, err
+			}
+
+			if options.Verbose {
+				logrus.Infof("Found provider set %s at %s:%d\n", set.varName, goFile, set.line)
+			}
+			result.Sets = append(result.Sets, migratedSet)
+		}
+
+		fileSets[goFile] = sets
+	}
+
+	for goFile := range fileSets {
+		editedFiles = append(editedFiles, goFile)
+	}
+	for goFile := range state.annotations {
+		if _, ok := fileSets[goFile]; !ok {
+			editedFiles = append(editedFiles, goFile)
+		}
+	}
+	sort.Strings(editedFiles)
+
+	for _, goFile := range editedFiles {
+		content, err := state.editFile(goFile, fileSets[goFile])
+		if err != nil {
+			return nil, err
+		}
+		if content == state.contents[goFile] {
+			continue
+		}
+
+		generatedFile := &models.GeneratedFile{
+			DirectoryPath: filepath.Dir(goFile),
+			FileName:      filepath.Base(goFile),
+			Content:       content,
+		}
+		result.Files = append(result.Files, generatedFile)
+
+		if options.DryRun {
+			continue
+		}
+
+		if err := m.fileRepository.WriteFile(generatedFile.DirectoryPath, generatedFile.FileName, generatedFile.Content); err != nil {
+			return nil, err
+		}
+
+		if options.Verbose {
+			logrus.Infof("Migrated %s\n", goFile)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *migration) readFile(filePath string) (string, error) {
+	if content, ok := s.contents[filePath]; ok {
+		return content, nil
+	}
+
+	content, err := s.service.fileRepository.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	s.contents[filePath] = content
+
+	return content, nil
+}
+
+func (s *migration) findFileSets(filePath string) ([]*providerSet, error) {
+	if strings.HasSuffix(filePath, "_test.go") {
+		return nil, nil
+	}
+
+	matched, err := s.service.fileRepository.ContainsAny(filePath, migrateMarkers)
+	if err != nil || !matched {
+		return nil, err
+	}
+
+	content, err := s.readFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !generator.IsProviderFile(&models.SourceFile{FilePath: filePath, Content: content}) {
+		return nil, nil
+	}
+
+	sets, err := findProviderSets(filePath, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrCannotParseFile, filePath, err)
+	}
+
+	return sets, nil
+}
+
+// For resolve the entries of a set to functions and decide what happens to its declaration
+func (s *migration) planSet(filePath string, set *providerSet, comment bool) (*models.MigratedSet, error) {
+	setName := generator.SetNameFromVarName(set.varName, s.naming)
+	migratedSet := &models.MigratedSet{
+		FilePath:   filePath,
+		Line:       set.line,
+		VarName:    set.varName,
+		SetName:    setName,
+		Annotation: generator.AnnotationComment(setName, s.style),
+		Functions:  make([]string, 0, len(set.entries)),
+		Unmigrated: make([]*models.UnmigratedEntry, 0),
+	}
+
+	for _, entry := range set.entries {
+		reason := entry.reason
+		if reason == "" {
+			var err error
+			reason, err = s.planEntry(filePath, set, entry, setName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if reason != "" {
+			migratedSet.Unmigrated = append(migratedSet.Unmigrated, &models.UnmigratedEntry{
+				Line:       entry.line,
+				Expression: entry.expression,
+				Reason:     reason,
+			})
+			continue
+		}
+
+		migratedSet.Functions = append(migratedSet.Functions, entry.expression)
+	}
+
+	// A set without any migrated function is left as it is, the remaining entries
+	// of a partly migrated set stay visible in the commented out declaration
+	if len(migratedSet.Functions) > 0 {
+		set.edit = true
+		set.remove = !comment && len(migratedSet.Unmigrated) == 0
+		migratedSet.Removed = set.remove
+		migratedSet.Commented = !set.remove
+	}
+
+	return migratedSet, nil
+}
+
+// For plan the annotation of one entry, the returned reason is set when it cannot be migrated
+func (s *migration) planEntry(filePath string, set *providerSet, entry *setEntry, setName string) (string, error) {
+	directory := filepath.Dir(filePath)
+
+	if entry.packageName != "" {
+		importPath := ""
+		for _, spec := range set.imports {
+			name, err := s.importName(spec)
+			if err != nil {
+				return "", err
+			}
+
+			if name == entry.packageName {
+				importPath = spec.path
+				break
+			}
+		}
+
+		if importPath == "" {
+			return "unknown package " + entry.packageName, nil
+		}
+
+		importDirectory, ok := s.importDirectory(importPath)
+		if !ok {
+			return "package outside the module", nil
+		}
+		directory = importDirectory
+	}
+
+	index, err := s.loadPackage(directory)
+	if err != nil {
+		return "", err
+	}
+
+	functionFile, ok := index.functions[entry.functionName]
+	if !ok {
+		return "not a top-level function", nil
+	}
+
+	if annotatedSet, ok := index.annotations[entry.functionName]; ok {
+		if annotatedSet != setName {
+			return "already annotated with " + generator.AnnotationComment(annotatedSet, s.style), nil
+		}
+
+		return "", nil
+	}
+
+	functionKey := directory + "." + entry.functionName
+	if plannedSet, ok := s.plannedFunctions[functionKey]; ok {
+		if plannedSet != setName {
+			return "already migrated to " + generator.AnnotationComment(plannedSet, s.style), nil
+		}
+
+		return "", nil
+	}
+	s.plannedFunctions[functionKey] = setName
+
+	if s.annotations[functionFile] == nil {
+		s.annotations[functionFile] = make(map[string]string)
+	}
+	s.annotations[functionFile][entry.functionName] = setName
+
+	return "", nil
+}
+
+// For get the local name of an import, the package clause is read for packages of the module
+func (s *migration) importName(spec *importSpec) (string, error) {
+	if spec.name != "" {
+		return spec.name, nil
+	}
+
+	if directory, ok := s.importDirectory(spec.path); ok {
+		index, err := s.loadPackage(directory)
+		if err != nil {
+			return "", err
+		}
+
+		if index.packageName != "" {
+			return index.packageName, nil
+		}
+	}
+
+	return generator.DefaultImportName(spec.path), nil
+}
+
+func (s *migration) importDirectory(importPath string) (string, bool) {
+	if importPath == s.moduleName {
+		return fileRepo.BASE_DIR, true
+	}
+
+	if !strings.HasPrefix(importPath, s.moduleName+"/") {
+		return "", false
+	}
+
+	return filepath.FromSlash(strings.TrimPrefix(importPath, s.moduleName+"/")), true
+}
+
+func (s *migration) loadPackage(directory string) (*packageIndex, error) {
+	if index, ok := s.packages[directory]; ok {
+		return index, nil
+	}
+
+	index := &packageIndex{
+		functions:   make(map[string]string),
+		annotations: make(map[string]string),
+	}
+
+	for _, goFile := range s.directories[directory] {
+		if strings.HasSuffix(goFile, "_test.go") {
+			continue
+		}
+
+		content, err := s.readFile(goFile)
+		if err != nil {
+			return nil, err
+		}
+		if !generator.IsProviderFile(&models.SourceFile{FilePath: goFile, Content: content}) {
+			continue
+		}
+
+		if index.packageName == "" {
+			if file, err := parser.ParseFile(token.NewFileSet(), goFile, content, parser.PackageClauseOnly); err == nil {
+				index.packageName = file.Name.Name
+			}
+		}
+
+		for functionName := range generator.ExtractFunctions(content) {
+			index.functions[functionName] = goFile
+		}
+
+		for _, annotation := range generator.ExtractAnnotations(content) {
+			if annotation.Err == nil {
+				index.annotations[annotation.FunctionName] = annotation.SetName
+			}
+		}
+	}
+
+	s.packages[directory] = index
+
+	return index, nil
+}
+
+// For apply the declaration edits and the annotations of a file
+func (s *migration) editFile(filePath string, sets []*providerSet) (string, error) {
+	content, err := s.readFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	edited := editDeclarations(content, sets)
+
+	// The function lines are found after the declarations moved them
+	if annotations, ok := s.annotations[filePath]; ok {
+		functions := generator.ExtractFunctions(edited)
+
+		suggestions := make([]*models.Suggestion, 0, len(annotations))
+		for functionName, setName := range annotations {
+			suggestions = append(suggestions, &models.Suggestion{
+				FilePath:     filePath,
+				Line:         functions[functionName],
+				FunctionName: functionName,
+				SetName:      setName,
+			})
+		}
+
+		edited = generator.ApplySuggestions(edited, suggestions, s.style)
+	}
+
+	if edited == content {
+		return content, nil
+	}
+
+	if len(sets) > 0 {
+		edited, err = removeUnusedImports(filePath, content, edited, func(importPath string) (string, error) {
+			return s.importName(&importSpec{path: importPath})
+		})
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", ErrCannotParseFile, filePath, err)
+		}
+	}
+
+	formatted, err := format.Source([]byte(edited))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrCannotParseFile, filePath, err)
+	}
+
+	return string(formatted), nil
+}
+
+// For rewrite the annotations of the project in one style, the style of the config by default
+func (m *migrateServiceImpl) ConvertAnnotations(ctx context.Context, options *models.ConvertOptions) (*models.ConvertResult, error) {
+	style := options.Style
+	if style == "" {
+		config, err := m.generatorService.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		style = config.Style
+	}
+	if err := generator.ValidateStyle(style); err != nil {
+		return nil, err
+	}
+	if style == "" {
+		style = generator.StyleAnnotation
+	}
+
+	goFiles, err := m.generatorService.ListGoFiles(ctx, options.Jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ConvertResult{
+		Style:       style,
+		Annotations: make([]*models.ConvertedAnnotation, 0),
+		Files:       make([]*models.GeneratedFile, 0),
+	}
+
+	for _, goFile := range goFiles {
+		matched, err := m.fileRepository.ContainsAny(goFile, convertMarkers)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		content, err := m.fileRepository.ReadFile(goFile)
+		if err != nil {
+			return nil, err
+		}
+		if !generator.IsProviderFile(&models.SourceFile{FilePath: goFile, Content: content}) {
+			continue
+		}
+
+		converted, annotations := generator.ConvertAnnotations(content, style)
+		if len(annotations) == 0 {
+			continue
+		}
+
+		for _, annotation := range annotations {
+			result.Annotations = append(result.Annotations, &models.ConvertedAnnotation{
+				FilePath:     goFile,
+				Line:         annotation.Line,
+				FunctionName: annotation.FunctionName,
+				SetName:      annotation.SetName,
+			})
+		}
+
+		generatedFile := &models.GeneratedFile{
+			DirectoryPath: filepath.Dir(goFile),
+			FileName:      filepath.Base(goFile),
+			Content:       converted,
+		}
+		result.Files = append(result.Files, generatedFile)
+
+		if options.DryRun {
+			continue
+		}
+
+		if err := m.fileRepository.WriteFile(generatedFile.DirectoryPath, generatedFile.FileName, generatedFile.Content); err != nil {
+			return nil, err
+		}
+
+		if options.Verbose {
+			logrus.Infof("Converted %d annotations in %s\n", len(annotations), goFile)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package migrate
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	mock_generator "github.com/graphzc/wiresetgen/internal/services/generator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMigrateWireSets_unusedImports(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"a/a.go": "package a\n\ntype A struct{}\n\nfunc NewA() *A { return &A{} }\n",
+		// The package clause does not match the directory
+		"transport/http/server.go": "package httpapi\n\ntype Server struct{}\n\nfunc NewServer() *Server { return &Server{} }\n",
+		"providers/providers.go": "package providers\n\nimport (\n\t\"io\"\n\n\t\"github.com/google/wire\"\n\n\t\"example.com/m/a\"\n\t\"example.com/m/transport/http\"\n)\n\n" +
+			"type Reader struct{}\n\nfunc (r *Reader) Read(p []byte) (int, error) { return 0, nil }\n\n" +
+			"func NewReader() *Reader { return &Reader{} }\n\n" +
+			"var ProviderSet = wire.NewSet(a.NewA, httpapi.NewServer, NewReader, wire.Bind(new(io.Reader), new(*Reader)))\n",
+	}
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().ContainsAny(mock.Anything, migrateMarkers).RunAndReturn(func(filePath string, markers []string) (bool, error) {
+		return filePath == "providers/providers.go", nil
+	})
+	fileRepository.EXPECT().ReadFile(mock.Anything).RunAndReturn(func(filePath string) (string, error) {
+		return files[filePath], nil
+	})
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ScanProject(mock.Anything, mock.Anything).Return(&models.ProjectScan{ModuleName: "example.com/m"}, nil)
+	generatorService.EXPECT().ListGoFiles(mock.Anything, 1).Return([]string{"a/a.go", "providers/providers.go", "transport/http/server.go"}, nil)
+	generatorService.EXPECT().LoadConfig().Return(&models.Config{}, nil)
+
+	service := NewMigrateService(fileRepository, generatorService)
+
+	result, err := service.MigrateWireSets(context.Background(), &models.MigrateOptions{DryRun: true, Jobs: 1})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Sets, 1) {
+		assert.Equal(t, []string{"a.NewA", "httpapi.NewServer", "NewReader"}, result.Sets[0].Functions)
+		assert.True(t, result.Sets[0].Commented)
+	}
+	if assert.Len(t, result.Files, 3) {
+		assert.Equal(t, "a.go", result.Files[0].FileName)
+		assert.Contains(t, result.Files[0].Content, "// @WireSet(\"Provider\")\nfunc NewA()")
+
+		content := result.Files[1].Content
+		file, err := parser.ParseFile(token.NewFileSet(), "providers/providers.go", content, parser.ImportsOnly)
+		assert.NoError(t, err)
+		assert.Empty(t, file.Imports)
+		assert.Contains(t, content, "// var ProviderSet = wire.NewSet(a.NewA, httpapi.NewServer, NewReader, wire.Bind(new(io.Reader), new(*Reader)))\n")
+
+		assert.Equal(t, "server.go", result.Files[2].FileName)
+		assert.Contains(t, result.Files[2].Content, "// @WireSet(\"Provider\")\nfunc NewServer()")
+	}
+}
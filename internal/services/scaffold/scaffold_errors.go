@@ -0,0 +1,10 @@
+package scaffold
+
+import "errors"
+
+var (
+	ErrDirectoryOutsideProject = errors.New("directory is outside the project")
+	ErrFileExists              = errors.New("file already exists")
+	ErrInjectorExists          = errors.New("directory already has a wireinject file")
+	ErrInvalidPackageName      = errors.New("invalid package name")
+)
@@ -0,0 +1,65 @@
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+)
+
+const defaultPackageName = "injector"
+
+// For read the package clause of a file, empty when it does not parse
+func extractPackageName(filePath string, content string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, content, parser.PackageClauseOnly)
+	if err != nil {
+		return ""
+	}
+
+	return file.Name.Name
+}
+
+// For turn the directory name into a package name, the module root uses the module name
+func packageNameFromDirectory(directory string, goModFile string) string {
+	name := filepath.Base(directory)
+	if directory == "." {
+		if moduleName, err := generator.GetModuleName(goModFile); err == nil {
+			name = generator.ImportPathBase(moduleName)
+		}
+	}
+
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r == '_' || unicode.IsLetter(r) || (unicode.IsDigit(r) && builder.Len() > 0) {
+			builder.WriteRune(r)
+		}
+	}
+
+	packageName := builder.String()
+	if !isValidPackageName(packageName) {
+		return defaultPackageName
+	}
+
+	return packageName
+}
+
+func isValidPackageName(packageName string) bool {
+	return token.IsIdentifier(packageName) && packageName != "_"
+}
+
+// go generate runs in the package directory, the generator needs the module root
+func generateCommand(directory string) string {
+	if directory == "." {
+		return "go run " + GeneratePackage + " generate"
+	}
+
+	root, err := filepath.Rel(directory, ".")
+	if err != nil {
+		return "go run " + GeneratePackage + " generate"
+	}
+
+	return "go run -C " + filepath.ToSlash(root) + " " + GeneratePackage + " generate"
+}
@@ -0,0 +1,42 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_packageNameFromDirectory(t *testing.T) {
+	t.Parallel()
+
+	goModFile := "module github.com/foo/bar-service/v2\n"
+
+	testCases := []struct {
+		name         string
+		directory    string
+		expectedName string
+	}{
+		{name: "Plain name", directory: "internal/wire", expectedName: "wire"},
+		{name: "Dash and upper case", directory: "cmd/My-API", expectedName: "myapi"},
+		{name: "Leading digit", directory: "cmd/2fa", expectedName: "fa"},
+		{name: "Keyword", directory: "internal/go", expectedName: defaultPackageName},
+		{name: "No letter", directory: "cmd/123", expectedName: defaultPackageName},
+		{name: "Module root", directory: ".", expectedName: "barservice"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expectedName, packageNameFromDirectory(tc.directory, goModFile))
+		})
+	}
+}
+
+func Test_generateCommand(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "go run "+GeneratePackage+" generate", generateCommand("."))
+	assert.Equal(t, "go run -C .. "+GeneratePackage+" generate", generateCommand("wire"))
+	assert.Equal(t, "go run -C ../.. "+GeneratePackage+" generate", generateCommand("cmd/api"))
+}
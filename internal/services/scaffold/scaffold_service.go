@@ -0,0 +1,184 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/graphzc/wiresetgen/internal/templates"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	InjectorFileName = "wire.go"
+	GenerateFileName = "wire_generate.go"
+	GeneratePackage  = "github.com/graphzc/wiresetgen/cmd/wiresetgen"
+)
+
+type Service interface {
+	Init(ctx context.Context, options *models.InitOptions) (*models.InitResult, error)
+}
+
+type scaffoldServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewScaffoldService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &scaffoldServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+func (s *scaffoldServiceImpl) Init(ctx context.Context, options *models.InitOptions) (*models.InitResult, error) {
+	goModFile, err := s.fileRepository.GetGoModFile()
+	if err != nil {
+		if errors.Is(err, fileRepo.ErrFileNotFound) {
+			return nil, generator.ErrIsNotProjectRoot
+		}
+
+		return nil, err
+	}
+
+	directory := filepath.Clean(options.Directory)
+	if filepath.IsAbs(directory) || directory == ".." || strings.HasPrefix(directory, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%w: %s", ErrDirectoryOutsideProject, options.Directory)
+	}
+
+	packageName, err := s.findPackageName(ctx, directory, goModFile, options.PackageName)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &models.InitTemplateModel{
+		PackageName:     packageName,
+		GenerateCommand: generateCommand(directory),
+	}
+
+	files := []*models.GeneratedFile{
+		{DirectoryPath: directory, FileName: InjectorFileName},
+	}
+	fileTemplates := []string{templates.InjectorTemplate}
+	if options.GoGenerate {
+		files = append(files, &models.GeneratedFile{DirectoryPath: directory, FileName: GenerateFileName})
+		fileTemplates = append(fileTemplates, templates.GenerateTemplate)
+	}
+
+	// Nothing is written when one of the files already exists
+	for _, file := range files {
+		exists, err := s.fileExists(filepath.Join(file.DirectoryPath, file.FileName))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: %s", ErrFileExists, filepath.Join(file.DirectoryPath, file.FileName))
+		}
+	}
+
+	result := &models.InitResult{
+		Files:   make([]*models.GeneratedFile, 0, len(files)+1),
+		Skipped: make([]string, 0),
+	}
+
+	// The config is shared by every injector of the project, an existing one is kept
+	configExists, err := s.fileExists(fileRepo.CONFIG_FILE)
+	if err != nil {
+		return nil, err
+	}
+	if configExists {
+		result.Skipped = append(result.Skipped, fileRepo.CONFIG_FILE)
+	} else {
+		files = append(files, &models.GeneratedFile{DirectoryPath: fileRepo.BASE_DIR, FileName: fileRepo.CONFIG_FILE})
+		fileTemplates = append(fileTemplates, templates.ConfigTemplate)
+	}
+
+	for i, file := range files {
+		content, err := renderTemplate(fileTemplates[i], model)
+		if err != nil {
+			return nil, err
+		}
+		file.Content = content
+
+		if err := s.fileRepository.WriteFile(file.DirectoryPath, file.FileName, file.Content); err != nil {
+			return nil, err
+		}
+
+		if options.Verbose {
+			logrus.Infof("Created %s\n", filepath.Join(file.DirectoryPath, file.FileName))
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	return result, nil
+}
+
+func (s *scaffoldServiceImpl) fileExists(filePath string) (bool, error) {
+	if _, err := s.fileRepository.StatFile(filePath); err != nil {
+		if errors.Is(err, fileRepo.ErrFileNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// For get the package of the directory from its files, or from the directory name when it has none
+func (s *scaffoldServiceImpl) findPackageName(ctx context.Context, directory string, goModFile string, packageName string) (string, error) {
+	goFiles, err := s.generatorService.ListGoFiles(ctx, 1)
+	if err != nil {
+		return "", err
+	}
+
+	for _, goFile := range goFiles {
+		if filepath.Dir(goFile) != directory || strings.HasSuffix(goFile, "_test.go") {
+			continue
+		}
+
+		content, err := s.fileRepository.ReadFile(goFile)
+		if err != nil {
+			return "", err
+		}
+
+		if strings.Contains(content, "//go:build wireinject") {
+			return "", fmt.Errorf("%w: %s", ErrInjectorExists, goFile)
+		}
+
+		if packageName == "" {
+			packageName = extractPackageName(goFile, content)
+		}
+	}
+
+	if packageName == "" {
+		packageName = packageNameFromDirectory(directory, goModFile)
+	}
+
+	if !isValidPackageName(packageName) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidPackageName, packageName)
+	}
+
+	return packageName, nil
+}
+
+func renderTemplate(text string, model *models.InitTemplateModel) (string, error) {
+	tmpl, err := template.New("init").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, model); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,58 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/repositories/files"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	mock_generator "github.com/graphzc/wiresetgen/internal/services/generator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().GetGoModFile().Return("module github.com/foo/bar\n", nil)
+	fileRepository.EXPECT().ReadFile("internal/app/app.go").Return("package application\n", nil)
+	fileRepository.EXPECT().StatFile("internal/app/wire.go").Return(nil, files.ErrFileNotFound)
+	fileRepository.EXPECT().StatFile(files.CONFIG_FILE).Return(&models.FileStat{}, nil)
+	fileRepository.EXPECT().WriteFile("internal/app", InjectorFileName, mock.Anything).Return(nil)
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ListGoFiles(mock.Anything, 1).Return([]string{"main.go", "internal/app/app.go", "internal/app/app_test.go"}, nil)
+
+	service := NewScaffoldService(fileRepository, generatorService)
+
+	result, err := service.Init(context.Background(), &models.InitOptions{Directory: "internal/app/"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{files.CONFIG_FILE}, result.Skipped)
+	if assert.Len(t, result.Files, 1) {
+		assert.Equal(t, "internal/app", result.Files[0].DirectoryPath)
+		assert.Contains(t, result.Files[0].Content, "//go:build wireinject\n\npackage application\n")
+	}
+}
+
+func TestInit_existingFile(t *testing.T) {
+	t.Parallel()
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().GetGoModFile().Return("module github.com/foo/bar\n", nil)
+	fileRepository.EXPECT().StatFile("wire/wire.go").Return(nil, files.ErrFileNotFound)
+	fileRepository.EXPECT().StatFile("wire/wire_generate.go").Return(&models.FileStat{}, nil)
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ListGoFiles(mock.Anything, 1).Return([]string{}, nil)
+
+	service := NewScaffoldService(fileRepository, generatorService)
+
+	result, err := service.Init(context.Background(), &models.InitOptions{Directory: "wire", GoGenerate: true})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrFileExists))
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_suggest
+
+import (
+	context "context"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+type Service_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Service) EXPECT() *Service_Expecter {
+	return &Service_Expecter{mock: &_m.Mock}
+}
+
+// SuggestAnnotations provides a mock function with given fields: ctx, options
+func (_m *Service) SuggestAnnotations(ctx context.Context, options *models.SuggestOptions) ([]*models.Suggestion, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestAnnotations")
+	}
+
+	var r0 []*models.Suggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SuggestOptions) ([]*models.Suggestion, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SuggestOptions) []*models.Suggestion); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Suggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SuggestOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_SuggestAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestAnnotations'
+type Service_SuggestAnnotations_Call struct {
+	*mock.Call
+}
+
+// SuggestAnnotations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.SuggestOptions
+func (_e *Service_Expecter) SuggestAnnotations(ctx interface{}, options interface{}) *Service_SuggestAnnotations_Call {
+	return &Service_SuggestAnnotations_Call{Call: _e.mock.On("SuggestAnnotations", ctx, options)}
+}
+
+func (_c *Service_SuggestAnnotations_Call) Run(run func(ctx context.Context, options *models.SuggestOptions)) *Service_SuggestAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SuggestOptions))
+	})
+	return _c
+}
+
+func (_c *Service_SuggestAnnotations_Call) Return(_a0 []*models.Suggestion, _a1 error) *Service_SuggestAnnotations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_SuggestAnnotations_Call) RunAndReturn(run func(context.Context, *models.SuggestOptions) ([]*models.Suggestion, error)) *Service_SuggestAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
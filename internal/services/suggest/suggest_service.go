@@ -0,0 +1,130 @@
+package suggest
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/sirupsen/logrus"
+)
+
+type Service interface {
+	SuggestAnnotations(ctx context.Context, options *models.SuggestOptions) ([]*models.Suggestion, error)
+}
+
+type suggestServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewSuggestService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &suggestServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+func (s *suggestServiceImpl) SuggestAnnotations(ctx context.Context, options *models.SuggestOptions) ([]*models.Suggestion, error) {
+	scan, err := s.generatorService.ScanProject(ctx, &models.GenerateOptions{
+		Verbose: options.Verbose,
+		NoCache: options.NoCache,
+		Jobs:    options.Jobs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the packages that already have a set can get suggestions
+	annotatedDirectories := make(map[string]bool)
+	for _, setInfo := range scan.SetInfos {
+		annotatedDirectories[filepath.Dir(setInfo.FilePath)] = true
+	}
+
+	goFiles, err := s.generatorService.ListGoFiles(ctx, options.Jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	packageFiles, directories, err := s.readPackageFiles(goFiles, annotatedDirectories)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.generatorService.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*models.Suggestion, 0)
+	for _, directory := range directories {
+		suggestions = append(suggestions, generator.SuggestAnnotations(packageFiles[directory])...)
+	}
+	for _, suggestion := range suggestions {
+		suggestion.Annotation = generator.AnnotationComment(suggestion.SetName, config.Style)
+	}
+
+	if options.Fix {
+		if err := s.applySuggestions(packageFiles, suggestions, config.Style, options.Verbose); err != nil {
+			return nil, err
+		}
+	}
+
+	return suggestions, nil
+}
+
+// For read the files of the given directories, the directories are returned in the files order
+func (s *suggestServiceImpl) readPackageFiles(goFiles []string, includedDirectories map[string]bool) (map[string][]*models.SourceFile, []string, error) {
+	packageFiles := make(map[string][]*models.SourceFile)
+	directories := make([]string, 0, len(includedDirectories))
+
+	for _, goFile := range goFiles {
+		directory := filepath.Dir(goFile)
+		if !includedDirectories[directory] {
+			continue
+		}
+
+		content, err := s.fileRepository.ReadFile(goFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, exists := packageFiles[directory]; !exists {
+			directories = append(directories, directory)
+		}
+		packageFiles[directory] = append(packageFiles[directory], &models.SourceFile{
+			FilePath: goFile,
+			Content:  content,
+		})
+	}
+
+	return packageFiles, directories, nil
+}
+
+func (s *suggestServiceImpl) applySuggestions(packageFiles map[string][]*models.SourceFile, suggestions []*models.Suggestion, style string, verbose bool) error {
+	fileSuggestions := make(map[string][]*models.Suggestion)
+	for _, suggestion := range suggestions {
+		fileSuggestions[suggestion.FilePath] = append(fileSuggestions[suggestion.FilePath], suggestion)
+	}
+
+	for _, sourceFiles := range packageFiles {
+		for _, sourceFile := range sourceFiles {
+			suggestions, ok := fileSuggestions[sourceFile.FilePath]
+			if !ok {
+				continue
+			}
+
+			content := generator.ApplySuggestions(sourceFile.Content, suggestions, style)
+			if err := s.fileRepository.WriteFile(filepath.Dir(sourceFile.FilePath), filepath.Base(sourceFile.FilePath), content); err != nil {
+				return err
+			}
+
+			if verbose {
+				logrus.Infof("Added %d annotations to %s\n", len(suggestions), sourceFile.FilePath)
+			}
+		}
+	}
+
+	return nil
+}
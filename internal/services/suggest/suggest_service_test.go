@@ -0,0 +1,86 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	mock_generator "github.com/graphzc/wiresetgen/internal/services/generator/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	serviceFile = "package user\n\n// @WireSet(\"User\")\nfunc NewService() *Service {\n\treturn nil\n}\n"
+	repoFile    = "package user\n\nfunc NewRepo() *Repo {\n\treturn nil\n}\n"
+)
+
+// For mock a project where user/repo.go misses the annotation of its package,
+// config has no set so its files are never read
+func newSuggestedProject(t *testing.T, config *models.Config) (*mock_files.Repository, *mock_generator.Service) {
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().ReadFile("user/service.go").Return(serviceFile, nil)
+	fileRepository.EXPECT().ReadFile("user/repo.go").Return(repoFile, nil)
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ScanProject(context.Background(), &models.GenerateOptions{Jobs: 1}).Return(&models.ProjectScan{
+		ModuleName: "example.com/m",
+		SetInfos:   []*models.WireSetInfo{{SetName: "User", FunctionName: "NewService", FilePath: "user/service.go"}},
+	}, nil)
+	generatorService.EXPECT().ListGoFiles(context.Background(), 1).Return([]string{"config/config.go", "user/repo.go", "user/service.go"}, nil)
+	generatorService.EXPECT().LoadConfig().Return(config, nil)
+
+	return fileRepository, generatorService
+}
+
+func TestSuggestAnnotations(t *testing.T) {
+	t.Parallel()
+
+	// Without --fix nothing is written, the mock fails on any WriteFile call
+	fileRepository, generatorService := newSuggestedProject(t, &models.Config{})
+	service := NewSuggestService(fileRepository, generatorService)
+
+	suggestions, err := service.SuggestAnnotations(context.Background(), &models.SuggestOptions{Jobs: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Suggestion{
+		{FilePath: "user/repo.go", Line: 3, FunctionName: "NewRepo", SetName: "User", Annotation: "// @WireSet(\"User\")"},
+	}, suggestions)
+}
+
+func TestSuggestAnnotations_fix(t *testing.T) {
+	t.Parallel()
+
+	fileRepository, generatorService := newSuggestedProject(t, &models.Config{Style: "directive"})
+	fileRepository.EXPECT().WriteFile("user", "repo.go", "package user\n\n//wiresetgen:set User\nfunc NewRepo() *Repo {\n\treturn nil\n}\n").Return(nil).Once()
+	service := NewSuggestService(fileRepository, generatorService)
+
+	suggestions, err := service.SuggestAnnotations(context.Background(), &models.SuggestOptions{Fix: true, Jobs: 1})
+
+	assert.NoError(t, err)
+	if assert.Len(t, suggestions, 1) {
+		assert.Equal(t, "//wiresetgen:set User", suggestions[0].Annotation)
+	}
+}
+
+func TestSuggestAnnotations_nothingToSuggest(t *testing.T) {
+	t.Parallel()
+
+	fileRepository := mock_files.NewRepository(t)
+	fileRepository.EXPECT().ReadFile("user/service.go").Return(serviceFile, nil)
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ScanProject(context.Background(), &models.GenerateOptions{Jobs: 1}).Return(&models.ProjectScan{
+		ModuleName: "example.com/m",
+		SetInfos:   []*models.WireSetInfo{{SetName: "User", FunctionName: "NewService", FilePath: "user/service.go"}},
+	}, nil)
+	generatorService.EXPECT().ListGoFiles(context.Background(), 1).Return([]string{"config/config.go", "user/service.go"}, nil)
+	generatorService.EXPECT().LoadConfig().Return(&models.Config{}, nil)
+
+	service := NewSuggestService(fileRepository, generatorService)
+
+	suggestions, err := service.SuggestAnnotations(context.Background(), &models.SuggestOptions{Fix: true, Jobs: 1})
+
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock_watcher
+
+import (
+	context "context"
+
+	models "github.com/graphzc/wiresetgen/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+type Service_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Service) EXPECT() *Service_Expecter {
+	return &Service_Expecter{mock: &_m.Mock}
+}
+
+// Watch provides a mock function with given fields: ctx, options
+func (_m *Service) Watch(ctx context.Context, options *models.WatchOptions) error {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WatchOptions) error); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type Service_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options *models.WatchOptions
+func (_e *Service_Expecter) Watch(ctx interface{}, options interface{}) *Service_Watch_Call {
+	return &Service_Watch_Call{Call: _e.mock.On("Watch", ctx, options)}
+}
+
+func (_c *Service_Watch_Call) Run(run func(ctx context.Context, options *models.WatchOptions)) *Service_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WatchOptions))
+	})
+	return _c
+}
+
+func (_c *Service_Watch_Call) Return(_a0 error) *Service_Watch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_Watch_Call) RunAndReturn(run func(context.Context, *models.WatchOptions) error) *Service_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
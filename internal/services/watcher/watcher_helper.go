@@ -0,0 +1,119 @@
+package watcher
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+)
+
+// For list the files that were added, modified or removed between two snapshots
+func diffSnapshots(previous map[string]models.FileStat, current map[string]models.FileStat) []string {
+	changedFiles := make([]string, 0)
+
+	for file, stat := range current {
+		previousStat, exists := previous[file]
+		if !exists || previousStat.Size != stat.Size || !previousStat.ModTime.Equal(stat.ModTime) {
+			changedFiles = append(changedFiles, file)
+		}
+	}
+
+	for file := range previous {
+		if _, exists := current[file]; !exists {
+			changedFiles = append(changedFiles, file)
+		}
+	}
+
+	sort.Strings(changedFiles)
+
+	return changedFiles
+}
+
+// For split the project scan back into the scan of each file
+func groupFileScans(scan *models.ProjectScan) map[string]*models.FileScan {
+	fileScans := make(map[string]*models.FileScan)
+
+	getFileScan := func(filePath string) *models.FileScan {
+		if _, exists := fileScans[filePath]; !exists {
+			fileScans[filePath] = &models.FileScan{}
+		}
+
+		return fileScans[filePath]
+	}
+
+	for _, setInfo := range scan.SetInfos {
+		fileScan := getFileScan(setInfo.FilePath)
+		fileScan.SetInfos = append(fileScan.SetInfos, setInfo)
+	}
+
+	for _, wireGenLocation := range scan.WireGenLocations {
+		getFileScan(wireGenLocation.FilePath).WireGenLocation = wireGenLocation
+	}
+
+	return fileScans
+}
+
+// For merge the file scans into a project scan, following the files order like a full scan does
+func mergeFileScans(moduleName string, files []string, fileScans map[string]*models.FileScan) *models.ProjectScan {
+	scan := &models.ProjectScan{
+		ModuleName:       moduleName,
+		SetInfos:         make([]*models.WireSetInfo, 0, 64),
+		WireGenLocations: make([]*models.WireGenLocation, 0, 4),
+	}
+
+	for _, file := range files {
+		fileScan, exists := fileScans[file]
+		if !exists {
+			continue
+		}
+
+		if fileScan.WireGenLocation != nil {
+			scan.WireGenLocations = append(scan.WireGenLocations, fileScan.WireGenLocation)
+		}
+
+		scan.SetInfos = append(scan.SetInfos, fileScan.SetInfos...)
+	}
+
+	return scan
+}
+
+// For pick the locations to regenerate, every location contains every set
+// so a set change affects all of them while a location change affects only itself
+func affectedWireGenLocations(wireGenLocations []*models.WireGenLocation, setsChanged bool, changedLocations map[string]bool) []*models.WireGenLocation {
+	if setsChanged {
+		return wireGenLocations
+	}
+
+	affected := make([]*models.WireGenLocation, 0, len(changedLocations))
+	for _, wireGenLocation := range wireGenLocations {
+		if changedLocations[wireGenLocation.DirectoryPath] {
+			affected = append(affected, wireGenLocation)
+		}
+	}
+
+	return affected
+}
+
+func sameSetInfos(previous *models.FileScan, current *models.FileScan) bool {
+	var previousSetInfos, currentSetInfos []*models.WireSetInfo
+	if previous != nil {
+		previousSetInfos = previous.SetInfos
+	}
+	if current != nil {
+		currentSetInfos = current.SetInfos
+	}
+
+	if len(previousSetInfos) == 0 && len(currentSetInfos) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(previousSetInfos, currentSetInfos)
+}
+
+func sameWireGenLocation(previous *models.FileScan, current *models.FileScan) bool {
+	if previous == nil || current == nil {
+		return previous == current
+	}
+
+	return reflect.DeepEqual(previous.WireGenLocation, current.WireGenLocation)
+}
@@ -0,0 +1,112 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		previous map[string]models.FileStat
+		current  map[string]models.FileStat
+		expected []string
+	}{
+		{
+			name:     "No changes",
+			previous: map[string]models.FileStat{"a.go": {Size: 1, ModTime: now}},
+			current:  map[string]models.FileStat{"a.go": {Size: 1, ModTime: now}},
+			expected: []string{},
+		},
+		{
+			name:     "Added, modified and removed files",
+			previous: map[string]models.FileStat{"a.go": {Size: 1, ModTime: now}, "b.go": {Size: 1, ModTime: now}, "c.go": {Size: 1, ModTime: now}},
+			current:  map[string]models.FileStat{"a.go": {Size: 1, ModTime: now}, "b.go": {Size: 1, ModTime: now.Add(time.Second)}, "d.go": {Size: 1, ModTime: now}},
+			expected: []string{"b.go", "c.go", "d.go"},
+		},
+		{
+			name:     "Size changed with the same modification time",
+			previous: map[string]models.FileStat{"a.go": {Size: 1, ModTime: now}},
+			current:  map[string]models.FileStat{"a.go": {Size: 2, ModTime: now}},
+			expected: []string{"a.go"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expected, diffSnapshots(tc.previous, tc.current))
+		})
+	}
+}
+
+func Test_affectedWireGenLocations(t *testing.T) {
+	t.Parallel()
+
+	wireGenLocations := []*models.WireGenLocation{
+		{PackageName: "api", DirectoryPath: "cmd/api"},
+		{PackageName: "worker", DirectoryPath: "cmd/worker"},
+	}
+
+	testCases := []struct {
+		name             string
+		setsChanged      bool
+		changedLocations map[string]bool
+		expected         []*models.WireGenLocation
+	}{
+		{
+			name:             "Set changes affect every location",
+			setsChanged:      true,
+			changedLocations: map[string]bool{},
+			expected:         wireGenLocations,
+		},
+		{
+			name:             "Location change affects only itself",
+			setsChanged:      false,
+			changedLocations: map[string]bool{"cmd/worker": true},
+			expected:         wireGenLocations[1:],
+		},
+		{
+			name:             "Nothing changed",
+			setsChanged:      false,
+			changedLocations: map[string]bool{},
+			expected:         []*models.WireGenLocation{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expected, affectedWireGenLocations(wireGenLocations, tc.setsChanged, tc.changedLocations))
+		})
+	}
+}
+
+func Test_mergeFileScans(t *testing.T) {
+	t.Parallel()
+
+	scan := &models.ProjectScan{
+		ModuleName: "github.com/foo/bar",
+		SetInfos: []*models.WireSetInfo{
+			{SetName: "Service", FunctionName: "NewA", FilePath: "a/a.go"},
+			{SetName: "Service", FunctionName: "NewB", FilePath: "b/b.go"},
+			{SetName: "Service", FunctionName: "NewB2", FilePath: "b/b.go"},
+		},
+		WireGenLocations: []*models.WireGenLocation{
+			{PackageName: "wire", DirectoryPath: "wire", FilePath: "wire/wire.go"},
+		},
+	}
+
+	merged := mergeFileScans(scan.ModuleName, []string{"a/a.go", "b/b.go", "c/c.go", "wire/wire.go"}, groupFileScans(scan))
+
+	assert.Equal(t, scan, merged)
+}
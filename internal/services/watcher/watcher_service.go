@@ -0,0 +1,276 @@
+package watcher
+
+import (
+	"context"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	fileRepo "github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"github.com/sirupsen/logrus"
+)
+
+type Service interface {
+	Watch(ctx context.Context, options *models.WatchOptions) error
+}
+
+type watcherServiceImpl struct {
+	fileRepository   fileRepo.Repository
+	generatorService generator.Service
+}
+
+func NewWatcherService(fileRepository fileRepo.Repository, generatorService generator.Service) Service {
+	return &watcherServiceImpl{
+		fileRepository:   fileRepository,
+		generatorService: generatorService,
+	}
+}
+
+func (w *watcherServiceImpl) Watch(ctx context.Context, options *models.WatchOptions) error {
+	// Every regeneration renders like the first one
+	generateOptions := &models.GenerateOptions{
+		Verbose:  options.Verbose,
+		NoCache:  options.NoCache,
+		Jobs:     options.Jobs,
+		Backend:  options.Backend,
+		Template: options.Template,
+	}
+
+	scan, err := w.generatorService.ScanProject(ctx, generateOptions)
+	if err != nil {
+		return err
+	}
+
+	generatedFiles, err := w.generatorService.WriteWireSets(scan, scan.WireGenLocations, generateOptions)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Generated %d wire set file(s), watching for changes\n", len(generatedFiles))
+
+	// Keep the scan of every file, so a change only rescans the changed files
+	fileScans := groupFileScans(scan)
+
+	// The config and the template are watched with the go files, a change of them regenerates every location
+	settingFiles, err := w.settingFiles(options.Template)
+	if err != nil {
+		return err
+	}
+
+	files, stats, err := w.takeSnapshot(ctx, options.Jobs, settingFiles)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	pendingFiles := make(map[string]bool)
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			currentFiles, currentStats, err := w.takeSnapshot(ctx, options.Jobs, settingFiles)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				logrus.Error("Error listing go files:", err)
+				continue
+			}
+
+			changedFiles := diffSnapshots(stats, currentStats)
+			files, stats = currentFiles, currentStats
+
+			if len(changedFiles) > 0 {
+				for _, file := range changedFiles {
+					pendingFiles[file] = true
+				}
+				lastChange = now
+
+				continue
+			}
+
+			// Wait until the tree is quiet for the debounce duration
+			if len(pendingFiles) == 0 || now.Sub(lastChange) < options.Debounce {
+				continue
+			}
+
+			// The changed files stay pending until a regeneration succeeds, a retry waits for the debounce again
+			if slices.ContainsFunc(settingFiles, func(settingFile string) bool { return pendingFiles[settingFile] }) {
+				var fullScan *models.ProjectScan
+				fullScan, err = w.regenerateAll(ctx, generateOptions)
+				if err == nil {
+					scan, fileScans = fullScan, groupFileScans(fullScan)
+
+					// The config may name another template, its stat is taken now so it is not seen as a change
+					var currentSettingFiles []string
+					currentSettingFiles, err = w.settingFiles(options.Template)
+					if err == nil {
+						settingFiles = currentSettingFiles
+						w.statSettingFiles(stats, settingFiles)
+					}
+				}
+			} else {
+				var updatedScans map[string]*models.FileScan
+				updatedScans, err = w.regenerate(scan.ModuleName, files, stats, fileScans, pendingFiles, generateOptions)
+				if err == nil {
+					fileScans = updatedScans
+				}
+			}
+			if err != nil {
+				logrus.Error("Error generating wire set:", err)
+				lastChange = now
+
+				continue
+			}
+
+			pendingFiles = make(map[string]bool)
+		}
+	}
+}
+
+// For get the config file and the template file of the options or the config, relative to the module root
+func (w *watcherServiceImpl) settingFiles(templatePath string) ([]string, error) {
+	settingFiles := []string{fileRepo.CONFIG_FILE}
+
+	if templatePath == "" {
+		config, err := w.generatorService.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		templatePath = config.Template
+	}
+	if templatePath != "" {
+		settingFiles = append(settingFiles, filepath.Clean(templatePath))
+	}
+
+	return settingFiles, nil
+}
+
+// For list every go file with its size and modification time, generated files are ignored
+// The setting files are only stat, a missing one is left out of the stats like a removed go file
+func (w *watcherServiceImpl) takeSnapshot(ctx context.Context, jobs int, settingFiles []string) ([]string, map[string]models.FileStat, error) {
+	goFiles, err := w.generatorService.ListGoFiles(ctx, jobs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make([]string, 0, len(goFiles))
+	stats := make(map[string]models.FileStat, len(goFiles))
+	for _, file := range goFiles {
+		if generator.IsGeneratedFileName(filepath.Base(file)) {
+			continue
+		}
+
+		stat, err := w.fileRepository.StatFile(file)
+		if err != nil {
+			// The file may be removed between listing and stat
+			continue
+		}
+
+		files = append(files, file)
+		stats[file] = *stat
+	}
+
+	w.statSettingFiles(stats, settingFiles)
+
+	return files, stats, nil
+}
+
+func (w *watcherServiceImpl) statSettingFiles(stats map[string]models.FileStat, settingFiles []string) {
+	for _, settingFile := range settingFiles {
+		if stat, err := w.fileRepository.StatFile(settingFile); err == nil {
+			stats[settingFile] = *stat
+		}
+	}
+}
+
+// For rescan the project and write every location, the config or the template changed
+func (w *watcherServiceImpl) regenerateAll(ctx context.Context, options *models.GenerateOptions) (*models.ProjectScan, error) {
+	scan, err := w.generatorService.ScanProject(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedFiles, err := w.generatorService.WriteWireSets(scan, scan.WireGenLocations, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, generatedFile := range generatedFiles {
+		logrus.Infof("Regenerated %s (settings changed)\n", path.Join(filepath.ToSlash(generatedFile.DirectoryPath), generatedFile.FileName))
+	}
+
+	return scan, nil
+}
+
+func (w *watcherServiceImpl) regenerate(
+	moduleName string,
+	files []string,
+	stats map[string]models.FileStat,
+	fileScans map[string]*models.FileScan,
+	pendingFiles map[string]bool,
+	options *models.GenerateOptions,
+) (map[string]*models.FileScan, error) {
+
+	// Work on a copy, so a failed regeneration still sees the pending files as changed on retry
+	fileScans = maps.Clone(fileScans)
+
+	setsChanged := false
+	changedLocations := make(map[string]bool)
+
+	for file := range pendingFiles {
+		previous := fileScans[file]
+
+		var current *models.FileScan
+		if _, exists := stats[file]; exists {
+			fileScan, err := w.generatorService.ScanFile(moduleName, file, options.Verbose)
+			if err != nil {
+				return nil, err
+			}
+
+			current = fileScan
+			fileScans[file] = current
+		} else {
+			delete(fileScans, file)
+		}
+
+		if !sameSetInfos(previous, current) {
+			setsChanged = true
+		}
+
+		if current != nil && current.WireGenLocation != nil && !sameWireGenLocation(previous, current) {
+			changedLocations[current.WireGenLocation.DirectoryPath] = true
+		}
+	}
+
+	scan := mergeFileScans(moduleName, files, fileScans)
+	wireGenLocations := affectedWireGenLocations(scan.WireGenLocations, setsChanged, changedLocations)
+	if len(wireGenLocations) == 0 {
+		if options.Verbose {
+			logrus.Infof("%d file(s) changed, no wire set changes\n", len(pendingFiles))
+		}
+
+		return fileScans, nil
+	}
+
+	generatedFiles, err := w.generatorService.WriteWireSets(scan, wireGenLocations, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, generatedFile := range generatedFiles {
+		logrus.Infof("Regenerated %s (%d file(s) changed)\n", path.Join(filepath.ToSlash(generatedFile.DirectoryPath), generatedFile.FileName), len(pendingFiles))
+	}
+
+	return fileScans, nil
+}
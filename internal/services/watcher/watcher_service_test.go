@@ -0,0 +1,173 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	mock_files "github.com/graphzc/wiresetgen/internal/repositories/files/mock"
+	mock_generator "github.com/graphzc/wiresetgen/internal/services/generator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// For mock a project with a config file, the changed file changes once after the first snapshot
+func newWatchedProject(t *testing.T, changedFile string) (*mock_files.Repository, *mock_generator.Service) {
+	now := time.Now()
+	location := &models.WireGenLocation{PackageName: "main", FilePath: "cmd/api/wire.go", DirectoryPath: "cmd/api"}
+	setInfo := &models.WireSetInfo{SetName: "Service", FunctionName: "NewService", FilePath: "service/service.go"}
+
+	fileRepository := mock_files.NewRepository(t)
+	for _, filePath := range []string{"cmd/api/wire.go", "service/service.go", ".wiresetgen.yaml"} {
+		if filePath == changedFile {
+			fileRepository.EXPECT().StatFile(filePath).Return(&models.FileStat{Size: 1, ModTime: now}, nil).Once()
+			fileRepository.EXPECT().StatFile(filePath).Return(&models.FileStat{Size: 2, ModTime: now}, nil)
+		} else {
+			fileRepository.EXPECT().StatFile(filePath).Return(&models.FileStat{Size: 1, ModTime: now}, nil)
+		}
+	}
+
+	generatorService := mock_generator.NewService(t)
+	generatorService.EXPECT().ScanProject(mock.Anything, mock.Anything).Return(&models.ProjectScan{
+		ModuleName:       "example.com/m",
+		SetInfos:         []*models.WireSetInfo{setInfo},
+		WireGenLocations: []*models.WireGenLocation{location},
+	}, nil)
+	generatorService.EXPECT().ListGoFiles(mock.Anything, 1).Return([]string{"cmd/api/wire.go", "service/service.go"}, nil)
+
+	return fileRepository, generatorService
+}
+
+func TestWatch_backendAndTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fileRepository, generatorService := newWatchedProject(t, "service/service.go")
+	fileRepository.EXPECT().StatFile("tools/providers.tmpl").Return(&models.FileStat{Size: 1}, nil)
+	generatorService.EXPECT().ScanFile("example.com/m", "service/service.go", false).Return(&models.FileScan{
+		SetInfos: []*models.WireSetInfo{{SetName: "Service", FunctionName: "NewOtherService", FilePath: "service/service.go"}},
+	}, nil)
+
+	writes := 0
+	generatorService.EXPECT().WriteWireSets(mock.Anything, mock.Anything, mock.MatchedBy(func(options *models.GenerateOptions) bool {
+		return options.Backend == "fx" && options.Template == "tools/providers.tmpl"
+	})).RunAndReturn(func(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+		// The first write is the initial generation, the second the regeneration
+		writes++
+		if writes == 2 {
+			cancel()
+		}
+
+		return []*models.GeneratedFile{}, nil
+	})
+
+	service := NewWatcherService(fileRepository, generatorService)
+
+	err := service.Watch(ctx, &models.WatchOptions{Interval: time.Millisecond, Jobs: 1, Backend: "fx", Template: "tools/providers.tmpl"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, writes)
+}
+
+func TestWatch_retryFailedRegeneration(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fileRepository, generatorService := newWatchedProject(t, "service/service.go")
+	generatorService.EXPECT().LoadConfig().Return(&models.Config{}, nil)
+	generatorService.EXPECT().ScanFile("example.com/m", "service/service.go", false).Return(&models.FileScan{
+		SetInfos: []*models.WireSetInfo{{SetName: "Service", FunctionName: "NewOtherService", FilePath: "service/service.go"}},
+	}, nil)
+
+	// The initial write succeeds, the regeneration fails once and the retry succeeds
+	writes := 0
+	generatorService.EXPECT().WriteWireSets(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+		writes++
+		switch writes {
+		case 2:
+			return nil, errors.New("disk full")
+		case 3:
+			cancel()
+		}
+
+		return []*models.GeneratedFile{}, nil
+	})
+
+	service := NewWatcherService(fileRepository, generatorService)
+
+	err := service.Watch(ctx, &models.WatchOptions{Interval: time.Millisecond, Jobs: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, writes)
+}
+
+func TestWatch_settingFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		template    string
+		config      *models.Config
+		changedFile string
+	}{
+		{
+			name:        "Config",
+			config:      &models.Config{},
+			changedFile: ".wiresetgen.yaml",
+		},
+		{
+			name:        "Template of the options",
+			template:    "tools/providers.tmpl",
+			changedFile: "tools/providers.tmpl",
+		},
+		{
+			name:        "Template of the config",
+			config:      &models.Config{Template: "tools/providers.tmpl"},
+			changedFile: "tools/providers.tmpl",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			fileRepository, generatorService := newWatchedProject(tt, tc.changedFile)
+			if tc.changedFile == "tools/providers.tmpl" {
+				fileRepository.EXPECT().StatFile("tools/providers.tmpl").Return(&models.FileStat{Size: 1}, nil).Once()
+				fileRepository.EXPECT().StatFile("tools/providers.tmpl").Return(&models.FileStat{Size: 2}, nil)
+			}
+			// The config names the template when the options do not
+			if tc.config != nil {
+				generatorService.EXPECT().LoadConfig().Return(tc.config, nil)
+			}
+
+			// A setting change rescans the project and writes every location, no file is scanned alone
+			writes := 0
+			generatorService.EXPECT().WriteWireSets(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(scan *models.ProjectScan, wireGenLocations []*models.WireGenLocation, options *models.GenerateOptions) ([]*models.GeneratedFile, error) {
+				writes++
+				if writes == 2 {
+					cancel()
+				}
+
+				return []*models.GeneratedFile{{DirectoryPath: "cmd/api", FileName: "wire_set_gen.go"}}, nil
+			})
+
+			service := NewWatcherService(fileRepository, generatorService)
+
+			err := service.Watch(ctx, &models.WatchOptions{Interval: time.Millisecond, Jobs: 1, Template: tc.template})
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, 2, writes)
+			generatorService.AssertNumberOfCalls(tt, "ScanProject", 2)
+		})
+	}
+}
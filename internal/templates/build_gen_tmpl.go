@@ -0,0 +1,24 @@
+package templates
+
+// The output is formatted, so the layout only needs the line breaks
+var BuildGenTemplate = `
+// Code generated by go-wireset-gen. DO NOT EDIT.
+
+package {{.PackageName}}
+{{ if .Imports }}
+import (
+{{ range .Imports }}{{ if .Alias }}{{ .Alias }} {{ end }}"{{ .Path }}"
+{{ end }})
+{{ end }}
+{{- with .Build }}
+// {{.Name}} calls the annotated providers in dependency order
+func {{.Name}}({{ range $i, $param := .Params }}{{ if $i }}, {{ end }}{{ $param.Name }} {{ $param.Type }}{{ end }}) {{ if eq (len .Results) 1 }}{{ index .Results 0 }}{{ else }}({{ join .Results ", " }}){{ end }} {
+{{ range .Steps }}{{ join .Vars ", " }} := {{ .Call }}
+{{ if .Error }}if err != nil {
+{{ range .Cleanups }}{{ . }}()
+{{ end }}return {{ join $.Build.ErrorReturns ", " }}
+}
+{{ end }}{{ end }}
+return {{ join .Returns ", " }}
+}
+{{ end }}`
@@ -0,0 +1,24 @@
+package templates
+
+// The output is formatted, so the layout only needs the line breaks
+var DigProvideGenTemplate = `
+// Code generated by go-wireset-gen. DO NOT EDIT.
+
+package {{.PackageName}}
+{{ if .WireSets }}
+import (
+{{ range .Imports }}{{ if .Alias }}{{ .Alias }} {{ end }}"{{ .Path }}"
+{{ end }}
+"go.uber.org/dig"
+)
+{{ end }}
+{{- range .WireSets }}
+// Provide{{.VarName}} provides the constructors of the {{.SetName}} set to the container
+func Provide{{.VarName}}(c *dig.Container) error {
+{{ range .Providers }}if err := c.Provide({{ .FuncPath }}{{ if .Name }}, dig.Name({{ printf "%q" .Name }}){{ end }}{{ if .Bind }}, dig.As(new({{ .Bind }})){{ end }}); err != nil {
+return err
+}
+{{ end }}
+return nil
+}
+{{ end }}`
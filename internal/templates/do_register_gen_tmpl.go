@@ -0,0 +1,31 @@
+package templates
+
+// The output is formatted, so the layout only needs the line breaks
+var DoRegisterGenTemplate = `
+// Code generated by go-wireset-gen. DO NOT EDIT.
+
+package {{.PackageName}}
+{{ if .WireSets }}
+import (
+{{ range .Imports }}{{ if .Alias }}{{ .Alias }} {{ end }}"{{ .Path }}"
+{{ end }}
+"github.com/samber/do"
+)
+{{ end }}
+{{- range .WireSets }}
+// Register{{.VarName}} registers the providers of the {{.SetName}} set
+func Register{{.VarName}}(i *do.Injector) {
+{{ range .Providers }}{{ $provider := . -}}
+do.Provide{{ if .Name }}Named{{ end }}(i, {{ if .Name }}{{ printf "%q" .Name }}, {{ end }}func(i *do.Injector) ({{ .Type }}, error) {
+{{ range .Params }}{{ .Name }}, err := do.Invoke[{{ .Type }}](i)
+if err != nil {
+return {{ $provider.Zero }}, err
+}
+{{ end }}return {{ .FuncPath }}({{ range $i, $param := .Params }}{{ if $i }}, {{ end }}{{ $param.Name }}{{ end }}){{ if not .Error }}, nil{{ end }}
+})
+{{ if .Bind }}do.Provide{{ if .Name }}Named{{ end }}(i, {{ if .Name }}{{ printf "%q" .Name }}, {{ end }}func(i *do.Injector) ({{ .Bind }}, error) {
+return do.Invoke{{ if .Name }}Named{{ end }}[{{ .Type }}](i{{ if .Name }}, {{ printf "%q" .Name }}{{ end }})
+})
+{{ end }}{{ end -}}
+}
+{{ end }}`
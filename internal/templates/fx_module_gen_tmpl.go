@@ -0,0 +1,28 @@
+package templates
+
+var FxModuleGenTemplate = `
+// Code generated by go-wireset-gen. DO NOT EDIT.
+
+package {{.PackageName}}
+{{ if .WireSets }}
+import (
+    {{ range .Imports }}
+    {{- if .Alias }}{{ .Alias }} {{ end }}"{{- .Path }}"
+    {{ end }}
+    "{{- "go.uber.org/fx" }}"
+)
+{{ end }}
+{{- range .WireSets }}
+var {{.VarName}} = fx.Module("{{.SetName}}",
+    fx.Provide(
+        {{ range .Providers }}
+        {{- if or .Name .Bind -}}
+        fx.Annotate({{ .FuncPath }}{{ if .Bind }}, fx.As(new({{ .Bind }})){{ end }}{{ if .Name }}, fx.ResultTags({{ printf "name:%q" .Name | printf "%q" }}){{ end }}),
+        {{- else -}}
+        {{ .FuncPath }},
+        {{- end }}
+        {{ end }}
+    ),
+)
+{{ end }}
+`
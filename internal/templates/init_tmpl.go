@@ -0,0 +1,27 @@
+package templates
+
+var InjectorTemplate = `//go:build wireinject
+
+package {{.PackageName}}
+
+// Injectors are written here, list the generated sets of wire_set_gen.go in wire.Build:
+//
+//	func InitializeApp() (*App, error) {
+//		wire.Build(AppSet)
+//		return nil, nil
+//	}
+`
+
+var GenerateTemplate = `package {{.PackageName}}
+
+//go:generate {{.GenerateCommand}}
+`
+
+var ConfigTemplate = `# wiresetgen configuration
+
+# Directories that are never scanned, a name matches at any depth,
+# a path with a slash matches from the module root
+exclude:
+  - vendor
+  - testdata
+`
@@ -0,0 +1,8 @@
+package templates
+
+var InjectorFuncTemplate = `
+// {{.Name}} builds {{.TypeName}} from {{ join .WireSets ", " }}
+func {{.Name}}({{ range $i, $param := .Params }}{{ if $i }}, {{ end }}{{ $param.Name }} {{ $param.Type }}{{ end }}) ({{ join .Results ", " }}) {
+	panic({{.WireName}}.Build({{ join .WireSets ", " }}))
+}
+`
@@ -7,16 +7,19 @@ package {{.PackageName}}
 
 import (
     {{ range .Imports }}
-    {{- .Alias }} "{{- .Path }}"
+    {{- if .Alias }}{{ .Alias }} {{ end }}"{{- .Path }}"
     {{ end }}
     "{{- "github.com/google/wire" }}"
 )
 
 {{ range .WireSets }}
-var {{.SetName}}Set = wire.NewSet(
-    {{ range .FuncPath }}
-    {{- .}},
+var {{.VarName}} = wire.NewSet(
+    {{ range .Providers }}
+    {{- .FuncPath }},
+    {{ if .Bind -}}
+    wire.Bind(new({{ .Bind }}), new({{ .Type }})),
     {{ end }}
+    {{- end }}
 )
 {{ end }}
 `
\ No newline at end of file
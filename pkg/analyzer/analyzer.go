@@ -0,0 +1,316 @@
+// Package analyzer provides a go/analysis Analyzer that checks @WireSet annotations
+// and their //wiresetgen:set directive form.
+//
+// It reports malformed annotations, unknown annotation keys, annotations that are not on a
+// top-level function, wire.Build arguments that name an unknown set and wire_set_gen.go files
+// that no longer match the annotations. Exported New* constructors of a package whose annotations
+// all name the same set are reported with a suggested fix that adds the annotation in the style
+// of .wiresetgen.yaml.
+// It can be run with go vet:
+//
+//	go vet -vettool=$(which wiresetgen-vet) ./...
+//
+// Sets are passed between packages as facts, so only the annotations of the analyzed package
+// and its dependencies are known. An annotated package that is not imported yet by the
+// wire_set_gen.go file is found by running wiresetgen generate, not by the analyzer.
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"golang.org/x/tools/go/analysis"
+)
+
+const Doc = `check @WireSet annotations and generated wire sets
+
+The wiresetgen analyzer reports malformed @WireSet annotations, annotations that are
+not followed by a top-level function, wire.Build arguments naming a set that has no
+annotation and wire_set_gen.go files that are out of date. Constructors missing the
+annotation shared by the rest of their package are reported with a suggested fix.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:             "wiresetgen",
+	Doc:              Doc,
+	Run:              run,
+	RunDespiteErrors: true,
+	FactTypes:        []analysis.Fact{new(setsFact)},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	sets := make(map[string][]string)
+	profiles := make(map[string][]string)
+	injectors := make([]*ast.File, 0)
+	sourceFiles := make([]*models.SourceFile, 0, len(pass.Files))
+	tokenFiles := make(map[string]*token.File)
+	generatedFiles := make(map[string]*ast.File)
+	generatedFileName := ""
+	ignoredSets := make(map[string]bool)
+	generatedFileIgnored := false
+
+	for _, file := range pass.Files {
+		tokenFile := pass.Fset.File(file.Pos())
+		if tokenFile == nil {
+			continue
+		}
+
+		// A location with several profiles has a file per profile, built with the tag of one of them,
+		// and a set whose providers are constrained has a file of its own
+		if fileName := filepath.Base(tokenFile.Name()); isGeneratedFile(fileName) {
+			generatedFiles[fileName] = file
+			generatedFileName = fileName
+			continue
+		}
+
+		content, err := readFile(pass, tokenFile.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if isInjectorFile(file) {
+			injectors = append(injectors, file)
+			continue
+		}
+
+		checkAnnotations(pass, tokenFile, string(content), sets, profiles)
+
+		sourceFiles = append(sourceFiles, &models.SourceFile{
+			FilePath: tokenFile.Name(),
+			Content:  string(content),
+		})
+		tokenFiles[tokenFile.Name()] = tokenFile
+	}
+
+	if err := suggestAnnotations(pass, sourceFiles, tokenFiles); err != nil {
+		return nil, err
+	}
+
+	// Injector files are excluded from a normal build by the wireinject tag
+	for _, fileName := range pass.IgnoredFiles {
+		if filepath.Ext(fileName) != ".go" {
+			continue
+		}
+
+		// The file of a profile is built only with the tag of the profile and the file of a constrained
+		// set only with its constraint, the sets it declares are not known to the build
+		if isGeneratedFile(filepath.Base(fileName)) {
+			generatedFileIgnored = true
+			if err := parseIgnoredSets(pass, fileName, ignoredSets); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		injector, err := parseIgnoredInjector(pass, fileName)
+		if err != nil {
+			return nil, err
+		}
+		if injector != nil {
+			injectors = append(injectors, injector)
+		}
+	}
+
+	if len(sets) > 0 {
+		fact := &setsFact{Sets: sets}
+		if len(profiles) > 0 {
+			fact.Profiles = profiles
+		}
+		pass.ExportPackageFact(fact)
+	}
+
+	if len(injectors) == 0 && len(generatedFiles) == 0 {
+		return nil, nil
+	}
+
+	config, directory, err := loadConfig(packageDirectory(pass))
+	if err != nil {
+		return nil, err
+	}
+
+	selectedProfiles, err := locationProfiles(pass, generatedFileName, injectors, config, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedSets := collectExpectedSets(pass, &setsFact{Sets: sets, Profiles: profiles}, &config.Naming, selectedProfiles)
+
+	// Without the tag of a profile or the constraint of the location the generated files are not built
+	// and the packages of their sets are not imported
+	if len(generatedFiles) == 0 && generatedFileIgnored {
+		return nil, nil
+	}
+
+	for _, injector := range injectors {
+		checkInjector(pass, injector, expectedSets, ignoredSets, len(generatedFiles) > 0)
+	}
+
+	if len(generatedFiles) > 0 {
+		checkGeneratedFiles(pass, generatedFiles, expectedSets, ignoredSets)
+	}
+
+	return nil, nil
+}
+
+// For report the annotations of a file that cannot be used and the unknown keys, an error is reported
+// at the token it was found at, and collect the usable annotations by set name,
+// with the profiles of the functions that have some
+func checkAnnotations(pass *analysis.Pass, tokenFile *token.File, content string, sets map[string][]string, profiles map[string][]string) {
+	for _, annotation := range generator.ExtractAnnotations(content) {
+		for _, warning := range annotation.Warnings {
+			pass.Reportf(annotationPos(tokenFile, annotation.Line, generator.AnnotationErrorColumn(warning, annotation.Column)), "%v", warning)
+		}
+
+		if annotation.Err != nil {
+			pass.Reportf(annotationPos(tokenFile, annotation.Line, generator.AnnotationErrorColumn(annotation.Err, annotation.Column)), "%v", annotation.Err)
+			continue
+		}
+
+		sets[annotation.SetName] = append(sets[annotation.SetName], annotation.FunctionName)
+		if len(annotation.Profiles) > 0 {
+			profiles[annotation.FunctionName] = annotation.Profiles
+		}
+	}
+}
+
+// For report the constructors that are missing the set annotation of their package,
+// the fix writes the annotation in the style of the config
+func suggestAnnotations(pass *analysis.Pass, sourceFiles []*models.SourceFile, tokenFiles map[string]*token.File) error {
+	suggestions := generator.SuggestAnnotations(sourceFiles)
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	config, _, err := loadConfig(packageDirectory(pass))
+	if err != nil {
+		return err
+	}
+
+	for _, suggestion := range suggestions {
+		pos := tokenFiles[suggestion.FilePath].LineStart(suggestion.Line)
+		annotation := generator.AnnotationComment(suggestion.SetName, config.Style)
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     pos,
+			Message: suggestion.FunctionName + " is missing " + annotation + ", the other constructors of the package are in the set",
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: "Add " + annotation,
+					TextEdits: []analysis.TextEdit{
+						{Pos: pos, End: pos, NewText: []byte(annotation + "\n")},
+					},
+				},
+			},
+		})
+	}
+
+	return nil
+}
+
+// For collect the providers of every known set by generated variable name
+// The providers are in the "importPath.FunctionName" form, the sets of an internal package
+// of another tree and the providers of another profile are left out like the generator does
+func collectExpectedSets(pass *analysis.Pass, sets *setsFact, naming *models.NamingConfig, selectedProfiles []string) map[string][]string {
+	expectedSets := make(map[string][]string)
+
+	addSets := func(importPath string, fact *setsFact) {
+		for setName, functionNames := range fact.Sets {
+			varName := generator.SetVarName(setName, naming)
+			for _, functionName := range functionNames {
+				if profiles := fact.Profiles[functionName]; len(profiles) > 0 && !slices.ContainsFunc(profiles, func(profile string) bool {
+					return slices.Contains(selectedProfiles, profile)
+				}) {
+					continue
+				}
+
+				expectedSets[varName] = append(expectedSets[varName], importPath+"."+functionName)
+			}
+		}
+	}
+
+	addSets(pass.Pkg.Path(), sets)
+	for _, packageFact := range pass.AllPackageFacts() {
+		if packageFact.Package == pass.Pkg || !generator.CanImport(packageFact.Package.Path(), pass.Pkg.Path()) {
+			continue
+		}
+
+		if fact, ok := packageFact.Fact.(*setsFact); ok {
+			addSets(packageFact.Package.Path(), fact)
+		}
+	}
+
+	for varName := range expectedSets {
+		sort.Strings(expectedSets[varName])
+	}
+
+	return expectedSets
+}
+
+// For report the wire.Build arguments that are neither declared by hand nor a known set
+// Without a generated file the provider packages are not imported, so their sets cannot be known
+// A set of a generated file that is not built is not checked
+func checkInjector(pass *analysis.Pass, injector *ast.File, expectedSets map[string][]string, ignoredSets map[string]bool, generated bool) {
+	declared := generator.TopLevelNames(injector)
+
+	for _, ident := range wireIdents(injector) {
+		if declared[ident.Name] || ignoredSets[ident.Name] || isDeclaredOutsideGeneratedFile(pass, ident.Name) {
+			continue
+		}
+
+		if !generated {
+			pass.Reportf(ident.Pos(), "%s is missing, run wiresetgen generate", generator.WireSetGenFileName)
+			return
+		}
+
+		if _, ok := expectedSets[ident.Name]; ok {
+			continue
+		}
+
+		pass.Reportf(ident.Pos(), "unknown wire set %s: no @WireSet annotation in this package or its imports", ident.Name)
+	}
+}
+
+// For report the generated sets that do not match the annotations, the sets of every generated file
+// of the package are checked together since a constrained set may move to a file of its own
+// A missing set is reported on the first file, a set of a file that is not built is not missing
+func checkGeneratedFiles(pass *analysis.Pass, files map[string]*ast.File, expectedSets map[string][]string, ignoredSets map[string]bool) {
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	generatedSets := make(generatedSets)
+	for _, fileName := range fileNames {
+		generatedSetProviders(pass, files[fileName], fileName, generatedSets)
+	}
+
+	missingSets := make([]string, 0)
+	for varName := range expectedSets {
+		if _, ok := generatedSets[varName]; !ok && !ignoredSets[varName] {
+			missingSets = append(missingSets, varName)
+		}
+	}
+	sort.Strings(missingSets)
+
+	for _, varName := range missingSets {
+		pass.Reportf(files[fileNames[0]].Name.Pos(), "%s is stale: %s is missing, run wiresetgen generate", fileNames[0], varName)
+	}
+
+	for _, generatedSet := range generatedSets.sorted() {
+		expected, ok := expectedSets[generatedSet.varName]
+		if !ok {
+			pass.Reportf(generatedSet.pos, "%s is stale: %s has no annotation, run wiresetgen generate", generatedSet.fileName, generatedSet.varName)
+			continue
+		}
+
+		if changes := diffProviders(expected, generatedSet.providers); len(changes) > 0 {
+			pass.Reportf(generatedSet.pos, "%s is stale: %s providers changed (%s), run wiresetgen generate", generatedSet.fileName, generatedSet.varName, strings.Join(changes, ", "))
+		}
+	}
+}
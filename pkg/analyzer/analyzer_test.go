@@ -0,0 +1,44 @@
+package analyzer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/graphzc/wiresetgen/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Collect the errors of analysistest instead of failing the test
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAnalyzer_annotations(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "annotations", "example.com/providers", "example.com/profiles")
+}
+
+func TestAnalyzer_suggestedFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer.Analyzer, "suggest")
+}
+
+func TestAnalyzer_injector(t *testing.T) {
+	// Build the injector files with the package, so their diagnostics can be matched
+	t.Setenv("GOFLAGS", "-tags=wireinject")
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "example.com/app", "example.com/fresh", "example.com/profiled", "example.com/platform")
+}
+
+func TestAnalyzer_ignoredInjector(t *testing.T) {
+	// Without the tag the injector is an ignored file, analysistest does not read its want comments
+	r := &recorder{}
+	analysistest.Run(r, analysistest.TestData(), analyzer.Analyzer, "example.com/app")
+
+	if assert.Len(t, r.errors, 1) {
+		assert.Contains(t, r.errors[0], "unexpected diagnostic: unknown wire set UnknownSet")
+	}
+}
@@ -0,0 +1,389 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+	"golang.org/x/tools/go/analysis"
+)
+
+// setsFact lists the annotated functions of a package by set name
+// and the profiles of the functions that are not in every profile
+type setsFact struct {
+	Sets     map[string][]string
+	Profiles map[string][]string
+}
+
+func (*setsFact) AFact() {}
+
+func (f *setsFact) String() string {
+	setNames := make([]string, 0, len(f.Sets))
+	for setName := range f.Sets {
+		setNames = append(setNames, setName)
+	}
+	sort.Strings(setNames)
+
+	parts := make([]string, 0, len(setNames))
+	for _, setName := range setNames {
+		functionNames := make([]string, 0, len(f.Sets[setName]))
+		for _, functionName := range f.Sets[setName] {
+			if profiles := f.Profiles[functionName]; len(profiles) > 0 {
+				functionName += "[" + strings.Join(profiles, ",") + "]"
+			}
+			functionNames = append(functionNames, functionName)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s: %s", setName, strings.Join(functionNames, ", ")))
+	}
+
+	return "sets(" + strings.Join(parts, "; ") + ")"
+}
+
+type generatedSet struct {
+	fileName  string
+	varName   string
+	pos       token.Pos
+	providers []string
+}
+
+type generatedSets map[string]*generatedSet
+
+func (g generatedSets) sorted() []*generatedSet {
+	sets := make([]*generatedSet, 0, len(g))
+	for _, set := range g {
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].fileName != sets[j].fileName {
+			return sets[i].fileName < sets[j].fileName
+		}
+
+		return sets[i].pos < sets[j].pos
+	})
+
+	return sets
+}
+
+// Drivers that do not provide Pass.ReadFile are given the real file system
+func readFile(pass *analysis.Pass, fileName string) ([]byte, error) {
+	if pass.ReadFile != nil {
+		return pass.ReadFile(fileName)
+	}
+
+	return os.ReadFile(fileName)
+}
+
+// The config of every module root read so far, a package is analyzed once but a module has many
+var moduleConfigs sync.Map
+
+// For get the .wiresetgen.yaml next to the go.mod of the package directory and the directory
+// of the package relative to the module root
+// A package outside a module or without a config uses the default config
+func loadConfig(directory string) (*models.Config, string, error) {
+	root := directory
+	for {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return &models.Config{}, directory, nil
+		}
+		root = parent
+	}
+
+	relative, err := filepath.Rel(root, directory)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if config, ok := moduleConfigs.Load(root); ok {
+		return config.(*models.Config), relative, nil
+	}
+
+	config := &models.Config{}
+	configFile, err := os.ReadFile(filepath.Join(root, files.CONFIG_FILE))
+	if err == nil {
+		config, err = generator.ParseConfig(string(configFile))
+	} else if os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	moduleConfigs.Store(root, config)
+
+	return config, relative, nil
+}
+
+// For get the profiles the generated sets of the package are built for: the profile of the file name,
+// else the profiles of the injector directive or of the config
+// A location with several profiles that is built without the tag of one is checked against all of them
+func locationProfiles(
+	pass *analysis.Pass,
+	generatedFileName string,
+	injectors []*ast.File,
+	config *models.Config,
+	directory string,
+) ([]string, error) {
+	if _, profile := generator.SplitProfileFileName(generatedFileName); profile != "" {
+		return []string{profile}, nil
+	}
+
+	profiles := config.Profiles[filepath.ToSlash(directory)]
+	for _, injector := range injectors {
+		tokenFile := pass.Fset.File(injector.Pos())
+		if tokenFile == nil {
+			continue
+		}
+
+		content, err := readFile(pass, tokenFile.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		// An invalid directive is reported by wiresetgen generate
+		if directive, err := generator.ExtractProfileDirective(strings.Split(string(content), "\n")); err == nil && len(directive) > 0 {
+			profiles = directive
+		}
+	}
+
+	return profiles, nil
+}
+
+// For get the directory of the analyzed package from its first file
+func packageDirectory(pass *analysis.Pass) string {
+	for _, file := range pass.Files {
+		if tokenFile := pass.Fset.File(file.Pos()); tokenFile != nil {
+			return filepath.Dir(tokenFile.Name())
+		}
+	}
+
+	for _, fileName := range pass.IgnoredFiles {
+		return filepath.Dir(fileName)
+	}
+
+	return "."
+}
+
+// For get the position of an annotation from its 1-based line and byte column
+func annotationPos(tokenFile *token.File, line int, column int) token.Pos {
+	if line > tokenFile.LineCount() {
+		return tokenFile.Pos(0)
+	}
+
+	return tokenFile.LineStart(line) + token.Pos(column-1)
+}
+
+// A file is an injector when its build constraint cannot be satisfied without the wireinject tag
+func isInjectorFile(file *ast.File) bool {
+	for _, commentGroup := range file.Comments {
+		if commentGroup.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range commentGroup.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				return false
+			}
+
+			return !expr.Eval(func(tag string) bool {
+				return tag != "wireinject"
+			})
+		}
+	}
+
+	return false
+}
+
+// For parse an ignored file of the package, return nil when it is not an injector
+func parseIgnoredInjector(pass *analysis.Pass, fileName string) (*ast.File, error) {
+	content, err := readFile(pass, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(string(content), "wireinject") {
+		return nil, nil
+	}
+
+	// A file that does not parse is reported by the compiler once the tag is set
+	file, err := parser.ParseFile(pass.Fset, fileName, content, parser.ParseComments)
+	if err != nil {
+		return nil, nil
+	}
+	if file.Name.Name != pass.Pkg.Name() || !isInjectorFile(file) {
+		return nil, nil
+	}
+
+	return file, nil
+}
+
+// For check if a file is a generated wire set file, with or without a profile and a constrained set,
+// e.g. wire_set_gen.go, wire_set_gen.dev.go or wire_set_gen_cache_set.go
+func isGeneratedFile(fileName string) bool {
+	base, _ := generator.SplitProfileFileName(fileName)
+	base, _ = generator.SplitSetFileName(base, generator.WireSetGenFileName)
+
+	return base == generator.WireSetGenFileName
+}
+
+// For get the sets declared by a generated file that is not built with the current tags
+func parseIgnoredSets(pass *analysis.Pass, fileName string, names map[string]bool) error {
+	content, err := readFile(pass, fileName)
+	if err != nil {
+		return err
+	}
+
+	// A file that does not parse is reported by the compiler once its constraint is satisfied
+	file, err := parser.ParseFile(pass.Fset, fileName, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+
+	for name := range generator.TopLevelNames(file) {
+		names[name] = true
+	}
+
+	return nil
+}
+
+func isDeclaredOutsideGeneratedFile(pass *analysis.Pass, name string) bool {
+	obj := pass.Pkg.Scope().Lookup(name)
+	if obj == nil {
+		return false
+	}
+
+	return !isGeneratedFile(filepath.Base(pass.Fset.Position(obj.Pos()).Filename))
+}
+
+// For get the plain identifiers given to wire.Build and wire.NewSet
+func wireIdents(file *ast.File) []*ast.Ident {
+	wireName := generator.ImportName(file, generator.WireImportPath)
+	if wireName == "" {
+		return nil
+	}
+
+	idents := make([]*ast.Ident, 0)
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if funcName := generator.WireFuncName(call, wireName); funcName != "Build" && funcName != "NewSet" {
+			return true
+		}
+
+		for _, arg := range call.Args {
+			if ident, ok := arg.(*ast.Ident); ok {
+				idents = append(idents, ident)
+			}
+		}
+
+		return true
+	})
+
+	return idents
+}
+
+// For add the sets of a generated file, the providers are in the "importPath.FunctionName" form
+func generatedSetProviders(pass *analysis.Pass, file *ast.File, fileName string, sets generatedSets) {
+
+	wireName := generator.ImportName(file, generator.WireImportPath)
+	importPaths := make(map[string]string)
+	for _, importSpec := range file.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		// An import without an alias is named by its package clause, that may differ from the path
+		name := generator.DefaultImportName(importPath)
+		if importSpec.Name != nil {
+			name = importSpec.Name.Name
+		} else if pkgName, ok := pass.TypesInfo.Implicits[importSpec].(*types.PkgName); ok {
+			name = pkgName.Imported().Name()
+		}
+		importPaths[name] = importPath
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || len(valueSpec.Names) != 1 || len(valueSpec.Values) != 1 {
+				continue
+			}
+
+			call, ok := valueSpec.Values[0].(*ast.CallExpr)
+			if !ok || generator.WireFuncName(call, wireName) != "NewSet" {
+				continue
+			}
+
+			set := &generatedSet{
+				fileName:  fileName,
+				varName:   valueSpec.Names[0].Name,
+				pos:       valueSpec.Names[0].Pos(),
+				providers: make([]string, 0, len(call.Args)),
+			}
+
+			for _, arg := range call.Args {
+				switch arg := arg.(type) {
+				case *ast.Ident:
+					set.providers = append(set.providers, pass.Pkg.Path()+"."+arg.Name)
+				case *ast.SelectorExpr:
+					if pkgIdent, ok := arg.X.(*ast.Ident); ok {
+						set.providers = append(set.providers, importPaths[pkgIdent.Name]+"."+arg.Sel.Name)
+					}
+				}
+			}
+			sort.Strings(set.providers)
+
+			sets[set.varName] = set
+		}
+	}
+}
+
+// For list the added providers with "+" and the removed providers with "-", both slices are sorted
+func diffProviders(expected []string, actual []string) []string {
+	changes := make([]string, 0)
+
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case j == len(actual) || (i < len(expected) && expected[i] < actual[j]):
+			changes = append(changes, "+"+expected[i])
+			i++
+		case i == len(expected) || actual[j] < expected[i]:
+			changes = append(changes, "-"+actual[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	return changes
+}
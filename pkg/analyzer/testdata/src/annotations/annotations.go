@@ -0,0 +1,34 @@
+package annotations // want package:"sets\\(Service: NewService, NewScoped, NewDirective\\)"
+
+type Service struct{}
+
+// @WireSet("Service")
+func NewService() *Service { return &Service{} }
+
+// @WireSet(Service) // want `malformed @WireSet annotation`
+func NewUnquoted() *Service { return &Service{} }
+
+// @WireSet("") // want `malformed @WireSet annotation`
+func NewEmpty() *Service { return &Service{} }
+
+// @WireSet("Service", bind=io.Reader) // want `malformed @WireSet annotation: bind=io.Reader must be a quoted string`
+func NewReader() *Service { return &Service{} }
+
+// @WireSet("Service", scope="app") // want `unknown annotation key: scope is ignored`
+func NewScoped() *Service { return &Service{} }
+
+// NewDirective is in the set of the directive
+//
+//wiresetgen:set Service
+func NewDirective() *Service { return &Service{} }
+
+//wiresetgen:set Service bind=io.Reader // want `malformed //wiresetgen directive: unexpected '/'`
+func NewCommented() *Service { return &Service{} }
+
+// @WireSet("Service") // want `@WireSet annotation must be followed by a top-level function`
+type Repository struct{}
+
+// @WireSet("Service") // want `@WireSet annotation must be followed by a top-level function`
+func (s *Service) New() *Service { return s }
+
+var marker = "@WireSet(\"Service\")"
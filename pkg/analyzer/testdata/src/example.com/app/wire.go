@@ -0,0 +1,14 @@
+//go:build wireinject
+
+package app
+
+import "github.com/google/wire"
+
+type App struct{}
+
+func NewApp() *App { return &App{} }
+
+func InitializeApp() *App {
+	wire.Build(ServiceSet, RepositorySet, UnknownSet, NewApp) // want `unknown wire set UnknownSet`
+	return nil
+}
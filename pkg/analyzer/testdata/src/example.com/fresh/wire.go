@@ -0,0 +1,12 @@
+//go:build wireinject
+
+package fresh
+
+import "github.com/google/wire"
+
+type App struct{}
+
+func InitializeApp() *App {
+	wire.Build(ServiceSet) // want `wire_set_gen.go is missing, run wiresetgen generate`
+	return nil
+}
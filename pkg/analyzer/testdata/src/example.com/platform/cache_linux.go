@@ -0,0 +1,6 @@
+package platform // want package:"sets\\(Cache: NewCache; Config: NewConfig\\)"
+
+// @WireSet("Cache")
+func NewCache() *Cache { return &Cache{} }
+
+func NewDiskCache() *Cache { return &Cache{} }
@@ -0,0 +1,4 @@
+package platform
+
+// @WireSet("Cache")
+func NewCache() *Cache { return &Cache{} }
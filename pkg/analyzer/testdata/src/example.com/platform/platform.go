@@ -0,0 +1,8 @@
+package platform
+
+type Cache struct{}
+
+type Config struct{}
+
+// @WireSet("Config")
+func NewConfig() *Config { return &Config{} }
@@ -0,0 +1,6 @@
+package platform
+
+type Tray struct{}
+
+// @WireSet("Tray")
+func NewTray() *Tray { return &Tray{} }
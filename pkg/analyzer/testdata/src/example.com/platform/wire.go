@@ -0,0 +1,10 @@
+//go:build wireinject
+
+package platform
+
+import "github.com/google/wire"
+
+func InitializeCache() *Cache {
+	wire.Build(CacheSet, ConfigSet)
+	return nil
+}
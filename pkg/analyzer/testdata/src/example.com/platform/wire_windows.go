@@ -0,0 +1,10 @@
+//go:build wireinject
+
+package platform
+
+import "github.com/google/wire"
+
+func InitializeTray() *Tray {
+	wire.Build(TraySet)
+	return nil
+}
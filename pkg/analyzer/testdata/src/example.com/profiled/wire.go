@@ -0,0 +1,14 @@
+//go:build wireinject
+
+//wiresetgen:profile dev
+package profiled
+
+import (
+	"example.com/profiles"
+	"github.com/google/wire"
+)
+
+func InitializeCache() *profiles.Cache {
+	wire.Build(CacheSet, ConfigSet)
+	return nil
+}
@@ -0,0 +1,14 @@
+package profiles // want package:"sets\\(Cache: NewMemoryCache\\[dev,test\\], NewRedisCache\\[prod\\]; Config: NewConfig\\)"
+
+type Cache struct{}
+
+type Config struct{}
+
+// @WireSet("Cache", profile="dev,test")
+func NewMemoryCache() *Cache { return &Cache{} }
+
+// @WireSet("Cache", profile="prod")
+func NewRedisCache() *Cache { return &Cache{} }
+
+// @WireSet("Config")
+func NewConfig() *Config { return &Config{} }
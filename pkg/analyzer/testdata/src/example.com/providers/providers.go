@@ -0,0 +1,16 @@
+package providers // want package:"sets\\(Repository: NewRepository; Service: NewService, NewCache\\)"
+
+type Service struct{}
+
+type Cache struct{}
+
+type Repository struct{}
+
+// @WireSet("Service")
+func NewService(repository *Repository) *Service { return &Service{} }
+
+// @WireSet("Service")
+func NewCache() *Cache { return &Cache{} }
+
+// @WireSet("Repository")
+func NewRepository() *Repository { return &Repository{} }
@@ -0,0 +1,7 @@
+package wire
+
+type ProviderSet struct{}
+
+func NewSet(...interface{}) ProviderSet { return ProviderSet{} }
+
+func Build(...interface{}) string { return "" }
@@ -0,0 +1,17 @@
+package suggest // want package:"sets\\(Service: NewService\\)"
+
+type Service struct{}
+
+type Client struct{}
+
+type Option struct{}
+
+// @WireSet("Service")
+func NewService(client *Client) *Service { return &Service{} }
+
+// NewClient creates the client used by the service
+func NewClient() *Client { return &Client{} } // want `NewClient is missing // @WireSet\("Service"\)`
+
+func Newline() string { return "\n" }
+
+func newOption() *Option { return &Option{} }
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ForEachParallel calls fn for every index in [0, n) using at most jobs goroutines.
+// When jobs is not positive it defaults to GOMAXPROCS.
+// The first error cancels the context passed to the remaining calls and is returned.
+func ForEachParallel(ctx context.Context, jobs int, n int, fn func(ctx context.Context, i int) error) error {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	if jobs > n {
+		jobs = n
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	indexes := make(chan int)
+
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range n {
+		select {
+		case <-ctx.Done():
+			break feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachParallel(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+
+	testCases := []struct {
+		name        string
+		jobs        int
+		n           int
+		failAt      int
+		expectedErr error
+	}{
+		{
+			name:        "Every index is visited",
+			jobs:        4,
+			n:           100,
+			failAt:      -1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Default jobs",
+			jobs:        0,
+			n:           10,
+			failAt:      -1,
+			expectedErr: nil,
+		},
+		{
+			name:        "No work",
+			jobs:        4,
+			n:           0,
+			failAt:      -1,
+			expectedErr: nil,
+		},
+		{
+			name:        "First error is returned",
+			jobs:        2,
+			n:           100,
+			failAt:      3,
+			expectedErr: errFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			visited := make([]int32, tc.n)
+			err := ForEachParallel(context.Background(), tc.jobs, tc.n, func(ctx context.Context, i int) error {
+				atomic.AddInt32(&visited[i], 1)
+				if i == tc.failAt {
+					return errFailed
+				}
+				return nil
+			})
+
+			assert.ErrorIs(tt, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				for i := range visited {
+					assert.Equal(tt, int32(1), visited[i])
+				}
+			}
+		})
+	}
+}
+
+func TestForEachParallel_canceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ForEachParallel(ctx, 2, 10, func(ctx context.Context, i int) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,103 @@
+package wiresetgen
+
+import (
+	"context"
+	"sort"
+)
+
+// SetDiff lists the providers a set gained or lost between two revisions.
+type SetDiff struct {
+	Name    string
+	Added   []*Provider
+	Removed []*Provider
+}
+
+// Diff compares the annotated sets of two revisions and returns the sets whose providers changed, sorted by name.
+// Nothing is written, neither the generated files nor the scan cache of the working tree.
+func Diff(ctx context.Context, options DiffOptions) ([]*SetDiff, error) {
+	from, err := Generate(ctx, Options{
+		Dir:     options.Dir,
+		Rev:     options.From,
+		Verbose: options.Verbose,
+		Jobs:    options.Jobs,
+		NoCache: true,
+		DryRun:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := Generate(ctx, Options{
+		Dir:     options.Dir,
+		Rev:     options.To,
+		Verbose: options.Verbose,
+		Jobs:    options.Jobs,
+		NoCache: true,
+		DryRun:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diffSets(from.Sets, to.Sets), nil
+}
+
+func providerKey(provider *Provider) string {
+	return provider.ImportPath + "." + provider.Function
+}
+
+func diffSets(from []*Set, to []*Set) []*SetDiff {
+	providers := func(sets []*Set) map[string]map[string]*Provider {
+		result := make(map[string]map[string]*Provider)
+		for _, set := range sets {
+			result[set.Name] = make(map[string]*Provider)
+			for _, provider := range set.Providers {
+				result[set.Name][providerKey(provider)] = provider
+			}
+		}
+		return result
+	}
+
+	fromProviders := providers(from)
+	toProviders := providers(to)
+
+	setNames := make(map[string]bool)
+	for setName := range fromProviders {
+		setNames[setName] = true
+	}
+	for setName := range toProviders {
+		setNames[setName] = true
+	}
+
+	// A provider missing on one side is added or removed, the maps of an absent set are nil
+	missing := func(left map[string]*Provider, right map[string]*Provider) []*Provider {
+		result := make([]*Provider, 0)
+		for key, provider := range left {
+			if _, exists := right[key]; !exists {
+				result = append(result, provider)
+			}
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return providerKey(result[i]) < providerKey(result[j])
+		})
+		return result
+	}
+
+	diffs := make([]*SetDiff, 0)
+	for setName := range setNames {
+		setDiff := &SetDiff{
+			Name:    setName,
+			Added:   missing(toProviders[setName], fromProviders[setName]),
+			Removed: missing(fromProviders[setName], toProviders[setName]),
+		}
+
+		if len(setDiff.Added) > 0 || len(setDiff.Removed) > 0 {
+			diffs = append(diffs, setDiff)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs
+}
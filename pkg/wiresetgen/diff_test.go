@@ -0,0 +1,101 @@
+package wiresetgen
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffSets(t *testing.T) {
+	t.Parallel()
+
+	newA := &Provider{Function: "NewA", ImportPath: "github.com/foo/bar/a"}
+	newB := &Provider{Function: "NewB", ImportPath: "github.com/foo/bar/b"}
+	newC := &Provider{Function: "NewC", ImportPath: "github.com/foo/bar/c"}
+
+	testCases := []struct {
+		name     string
+		from     []*Set
+		to       []*Set
+		expected []*SetDiff
+	}{
+		{
+			name:     "No changes",
+			from:     []*Set{{Name: "Service", Providers: []*Provider{newA}}},
+			to:       []*Set{{Name: "Service", Providers: []*Provider{newA}}},
+			expected: []*SetDiff{},
+		},
+		{
+			name: "Providers added and removed",
+			from: []*Set{{Name: "Service", Providers: []*Provider{newA, newB}}},
+			to:   []*Set{{Name: "Service", Providers: []*Provider{newA, newC}}},
+			expected: []*SetDiff{
+				{Name: "Service", Added: []*Provider{newC}, Removed: []*Provider{newB}},
+			},
+		},
+		{
+			name: "Set added and set removed",
+			from: []*Set{{Name: "Old", Providers: []*Provider{newA}}},
+			to:   []*Set{{Name: "New", Providers: []*Provider{newB, newA}}},
+			expected: []*SetDiff{
+				{Name: "New", Added: []*Provider{newA, newB}, Removed: []*Provider{}},
+				{Name: "Old", Added: []*Provider{}, Removed: []*Provider{newA}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			assert.Equal(tt, tc.expected, diffSets(tc.from, tc.to))
+		})
+	}
+}
+
+func TestDiff_workingTree(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	writeFile := func(filePath string, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(filePath)), os.ModePerm))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, filePath), []byte(content), 0644))
+	}
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+
+	writeFile("go.mod", "module github.com/foo/bar\n\ngo 1.23\n")
+	writeFile("service/service.go", "package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n")
+	writeFile("wire/wire.go", "//go:build wireinject\n\npackage wire\n")
+	run("init", "-q")
+	run("add", "-A")
+	run("commit", "-q", "-m", "snapshot")
+
+	// The working tree gains a provider
+	writeFile("service/repo.go", "package service\n\n// @WireSet(\"Service\")\nfunc NewRepo() *Repo {\n\treturn nil\n}\n")
+
+	setDiffs, err := Diff(context.Background(), DiffOptions{Dir: dir, From: "HEAD", Jobs: 1})
+
+	assert.NoError(t, err)
+	if assert.Len(t, setDiffs, 1) && assert.Len(t, setDiffs[0].Added, 1) {
+		assert.Equal(t, "NewRepo", setDiffs[0].Added[0].Function)
+	}
+	assert.NoDirExists(t, filepath.Join(dir, ".wiresetgen"))
+	assert.NoFileExists(t, filepath.Join(dir, "wire", "wire_set_gen.go"))
+}
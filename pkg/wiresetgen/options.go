@@ -0,0 +1,62 @@
+package wiresetgen
+
+import "io/fs"
+
+// Options controls a single generation run.
+type Options struct {
+	// Dir is the module root containing go.mod, defaults to the working directory.
+	Dir string
+
+	// FS reads the module from a file system instead of Dir, e.g. fstest.MapFS or a zip.Reader.
+	// The root of FS holds go.mod, FS is never written to and the cache is not used.
+	FS fs.FS
+
+	// Rev reads the module from a git revision of the repository containing Dir, without checking it out.
+	// Like FS, the revision is never written to and the cache is not used. The git commands are stopped
+	// when the context of Generate is done.
+	Rev string
+
+	// Overlay maps paths relative to the root of FS or Rev to contents that replace or add to its files,
+	// e.g. unsaved editor buffers. Unless DryRun is set the generated files are written into it, so a later
+	// run reads them back, a file it already has keeps its key. Without an Overlay the generated files are only
+	// returned in the result.
+	// It is not used with Dir and must not be accessed while Generate runs.
+	Overlay map[string]string
+
+	// Verbose logs every annotation and generated file.
+	Verbose bool
+
+	// NoCache scans every file without reading or writing the scan cache.
+	NoCache bool
+
+	// Jobs is the number of files scanned concurrently, defaults to GOMAXPROCS.
+	Jobs int
+
+	// DryRun renders the generated files into the result without writing them.
+	DryRun bool
+
+	// Backend selects the generated output, "wire" for wire_set_gen.go, "fx" for fx_module_gen.go,
+	// "plain" for a Build function without any DI library in build_gen.go, "do" for do_register_gen.go
+	// or "dig" for dig_provide_gen.go. It is the default of the locations without a //wiresetgen:backend
+	// directive or a backends entry in .wiresetgen.yaml, else the backend of .wiresetgen.yaml or "wire".
+	Backend string
+
+	// Template is a text/template file, relative to the module root, rendered instead of the backend template.
+	// The backend still names the file, the output is checked like ErrInvalidTemplateOutput describes and formatted. Defaults to the template of .wiresetgen.yaml.
+	Template string
+}
+
+// DiffOptions selects the revisions compared by Diff.
+type DiffOptions struct {
+	// Dir is the module root inside the git repository, defaults to the working directory.
+	Dir string
+
+	// From is the git revision of the old side.
+	From string
+
+	// To is the git revision of the new side, the working tree when empty.
+	To string
+
+	Verbose bool
+	Jobs    int
+}
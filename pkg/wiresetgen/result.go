@@ -0,0 +1,68 @@
+package wiresetgen
+
+// Result describes what a generation run found and produced.
+type Result struct {
+	// ModuleName is the module path read from go.mod.
+	ModuleName string
+
+	// Sets are the annotated wire sets sorted by name.
+	Sets []*Set
+
+	// Locations are the directories of the wireinject files, in scan order.
+	Locations []*Location
+
+	// Files are the generated files, one per location, profile and constrained set.
+	Files []*File
+
+	// Warnings are the annotation problems that did not stop the run with their position,
+	// e.g. an unknown key or a malformed annotation that was left out.
+	Warnings []string
+}
+
+// Set is a wire set built from every function annotated with its name.
+type Set struct {
+	Name      string
+	Providers []*Provider
+}
+
+// Provider is an annotated constructor.
+type Provider struct {
+	Function    string
+	PackageName string
+	ImportPath  string
+	FilePath    string
+
+	// ParamTypes and ResultTypes are qualified with the full import path,
+	// e.g. *github.com/foo/bar.Service, they are empty when the file does not parse.
+	ParamTypes  []string
+	ResultTypes []string
+
+	// Profiles are the profiles of the annotation, the provider is in every profile when empty.
+	Profiles []string
+
+	// Constraint is the build constraint of the provider file, empty when it is always built.
+	Constraint string
+}
+
+// Location is a directory that receives a generated file.
+type Location struct {
+	PackageName  string
+	Directory    string
+	InjectorFile string
+
+	// Profiles are the profiles of the //wiresetgen:profile directive.
+	Profiles []string
+
+	// Constraint is the build constraint of the injector file without the wireinject tag.
+	Constraint string
+}
+
+// File is a generated file, the path is relative to Options.Dir.
+type File struct {
+	Path    string
+	Content string
+	Written bool
+
+	// Skipped are the sets left out of the file with the reason, e.g. the sets of an internal package of another tree.
+	Skipped []string
+}
@@ -0,0 +1,167 @@
+// Package wiresetgen generates google/wire provider sets, or uber/fx modules, from @WireSet annotations.
+//
+// It is the library form of the wiresetgen command:
+//
+//	result, err := wiresetgen.Generate(ctx, wiresetgen.Options{Dir: "path/to/module"})
+package wiresetgen
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/graphzc/wiresetgen/internal/models"
+	"github.com/graphzc/wiresetgen/internal/repositories/cache"
+	"github.com/graphzc/wiresetgen/internal/repositories/files"
+	"github.com/graphzc/wiresetgen/internal/services/generator"
+)
+
+var (
+	// ErrIsNotProjectRoot is returned when Options.Dir has no go.mod file.
+	ErrIsNotProjectRoot = generator.ErrIsNotProjectRoot
+
+	// ErrInvalidGoModFile is returned when go.mod has no module line.
+	ErrInvalidGoModFile = generator.ErrInvalidGoModFile
+
+	// ErrUnknownBackend is returned when Options.Backend names no known backend.
+	ErrUnknownBackend = generator.ErrUnknownBackend
+
+	// ErrInvalidTemplate is returned when Options.Template cannot be read or parsed.
+	ErrInvalidTemplate = generator.ErrInvalidTemplate
+
+	// ErrInvalidTemplateOutput is returned when the output of Options.Template does not parse, is in another package,
+	// has an unused import or qualifies a name that is neither imported nor declared by the package.
+	ErrInvalidTemplateOutput = generator.ErrInvalidTemplateOutput
+
+	// ErrDeclaredName is returned when the package of a location already declares a name the backend generates,
+	// e.g. the Build function of the plain backend.
+	ErrDeclaredName = generator.ErrDeclaredName
+
+	// ErrInvalidSetName is returned when an annotation names a set that is not a Go identifier,
+	// or the naming of .wiresetgen.yaml turns a set name into one that is not.
+	ErrInvalidSetName = generator.ErrInvalidSetName
+
+	// ErrDuplicateSetName is returned when two sets get the same variable name, e.g. Service and ServiceSet.
+	ErrDuplicateSetName = generator.ErrDuplicateSetName
+
+	// ErrUnimportablePackage is returned when a provider is in a package that another location cannot import,
+	// e.g. a main package or a testdata directory.
+	ErrUnimportablePackage = generator.ErrUnimportablePackage
+
+	// ErrInternalPackage is returned when only some providers of a set are in an internal package that a location
+	// cannot import, a set that the location cannot import at all is left out and listed in File.Skipped.
+	ErrInternalPackage = generator.ErrInternalPackage
+
+	// ErrInvalidProfile is returned when an annotation, a //wiresetgen:profile directive or .wiresetgen.yaml
+	// has a profile that cannot be a build tag.
+	ErrInvalidProfile = generator.ErrInvalidProfile
+
+	// ErrMalformedDirective is returned when a //wiresetgen:backend or //wiresetgen:profile directive
+	// does not parse, the error has its position. A malformed //wiresetgen:set directive is a warning.
+	ErrMalformedDirective = generator.ErrMalformedDirective
+
+	// ErrInvalidConstraint is returned when .wiresetgen.yaml has a location constraint that is not
+	// a valid //go:build expression.
+	ErrInvalidConstraint = generator.ErrInvalidConstraint
+
+	// ErrRevisionNotFound is returned when Options.Rev is not a commit of the repository.
+	ErrRevisionNotFound = files.ErrRevisionNotFound
+)
+
+// Generate scans the module and writes a wire_set_gen.go file, or the file of Options.Backend, next to every wireinject file.
+// Nothing is written when Options.DryRun is set. A module read from Options.FS or Options.Rev is never
+// written to, the generated files go to Options.Overlay and nothing is written when it is nil.
+func Generate(ctx context.Context, options Options) (*Result, error) {
+	dir := options.Dir
+	if dir == "" {
+		dir = files.BASE_DIR
+	}
+
+	fileRepository := files.NewFileRepository(dir)
+	generateOptions := &models.GenerateOptions{
+		Verbose:  options.Verbose,
+		NoCache:  options.NoCache,
+		Jobs:     options.Jobs,
+		DryRun:   options.DryRun,
+		Backend:  options.Backend,
+		Template: options.Template,
+	}
+
+	// The overlay shadows the sources and receives the generated files
+	if options.FS != nil {
+		fileRepository = files.NewFSRepository(options.FS, files.NewMapOverlay(options.Overlay))
+	} else if options.Rev != "" {
+		fileRepository = files.NewGitRepository(ctx, dir, options.Rev, files.NewMapOverlay(options.Overlay))
+	}
+
+	if options.FS != nil || options.Rev != "" {
+		generateOptions.NoCache = true
+		if options.Overlay == nil {
+			generateOptions.DryRun = true
+		}
+	}
+
+	generatorService := generator.NewGenerateService(fileRepository, cache.NewCacheRepository(dir))
+
+	generateResult, err := generatorService.GenerateWireSet(ctx, generateOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResult(generateResult, !generateOptions.DryRun), nil
+}
+
+func newResult(generateResult *models.GenerateResult, written bool) *Result {
+	result := &Result{
+		ModuleName: generateResult.Scan.ModuleName,
+		Sets:       make([]*Set, 0),
+		Locations:  make([]*Location, 0, len(generateResult.Scan.WireGenLocations)),
+		Files:      make([]*File, 0, len(generateResult.Files)),
+		Warnings:   generateResult.Scan.Warnings,
+	}
+
+	sets := make(map[string]*Set)
+	for _, setInfo := range generateResult.Scan.SetInfos {
+		set, exists := sets[setInfo.SetName]
+		if !exists {
+			set = &Set{Name: setInfo.SetName}
+			sets[setInfo.SetName] = set
+			result.Sets = append(result.Sets, set)
+		}
+
+		set.Providers = append(set.Providers, &Provider{
+			Function:    setInfo.FunctionName,
+			PackageName: setInfo.PackageName,
+			ImportPath:  setInfo.ImportPath,
+			FilePath:    setInfo.FilePath,
+			ParamTypes:  setInfo.ParamTypes,
+			ResultTypes: setInfo.ResultTypes,
+			Profiles:    setInfo.Profiles,
+			Constraint:  setInfo.Constraint,
+		})
+	}
+	sort.Slice(result.Sets, func(i, j int) bool {
+		return result.Sets[i].Name < result.Sets[j].Name
+	})
+
+	for _, wireGenLocation := range generateResult.Scan.WireGenLocations {
+		result.Locations = append(result.Locations, &Location{
+			PackageName:  wireGenLocation.PackageName,
+			Directory:    wireGenLocation.DirectoryPath,
+			InjectorFile: wireGenLocation.FilePath,
+			Profiles:     wireGenLocation.Profiles,
+			Constraint:   wireGenLocation.Constraint,
+		})
+	}
+
+	for _, generatedFile := range generateResult.Files {
+		result.Files = append(result.Files, &File{
+			Path:    filepath.Join(generatedFile.DirectoryPath, generatedFile.FileName),
+			Content: generatedFile.Content,
+			Written: written,
+			Skipped: generatedFile.Skipped,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,830 @@
+package wiresetgen
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for filePath, content := range files {
+		fullPath := filepath.Join(root, filePath)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), os.ModePerm))
+		assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	root := writeModule(t, map[string]string{
+		"go.mod":             "module github.com/foo/bar\n\ngo 1.23\n",
+		"service/service.go": "package service\n\n// @WireSet(\"Service\")\nfunc NewService(name string) *Service {\n\treturn nil\n}\n",
+		"wire/wire.go":       "//go:build wireinject\n\npackage wire\n",
+	})
+
+	testCases := []struct {
+		name          string
+		dryRun        bool
+		expectWritten bool
+	}{
+		{
+			name:          "Dry run",
+			dryRun:        true,
+			expectWritten: false,
+		},
+		{
+			name:          "Write files",
+			dryRun:        false,
+			expectWritten: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			result, err := Generate(context.Background(), Options{
+				Dir:     root,
+				NoCache: true,
+				DryRun:  tc.dryRun,
+			})
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, "github.com/foo/bar", result.ModuleName)
+			assert.Equal(tt, []*Set{
+				{
+					Name: "Service",
+					Providers: []*Provider{
+						{
+							Function:    "NewService",
+							PackageName: "service",
+							ImportPath:  "github.com/foo/bar/service",
+							FilePath:    filepath.Join("service", "service.go"),
+							ParamTypes:  []string{"string"},
+							ResultTypes: []string{"*github.com/foo/bar/service.Service"},
+						},
+					},
+				},
+			}, result.Sets)
+			assert.Equal(tt, []*Location{
+				{
+					PackageName:  "wire",
+					Directory:    "wire",
+					InjectorFile: filepath.Join("wire", "wire.go"),
+				},
+			}, result.Locations)
+
+			if assert.Len(tt, result.Files, 1) {
+				assert.Equal(tt, filepath.Join("wire", "wire_set_gen.go"), result.Files[0].Path)
+				assert.Contains(tt, result.Files[0].Content, "service.NewService")
+				assert.Equal(tt, tc.expectWritten, result.Files[0].Written)
+
+				_, statErr := os.Stat(filepath.Join(root, result.Files[0].Path))
+				assert.Equal(tt, tc.expectWritten, statErr == nil)
+			}
+		})
+	}
+}
+
+func TestGenerate_notProjectRoot(t *testing.T) {
+	t.Parallel()
+
+	result, err := Generate(context.Background(), Options{Dir: t.TempDir()})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrIsNotProjectRoot)
+}
+
+func TestGenerate_fs(t *testing.T) {
+	t.Parallel()
+
+	sources := map[string]string{
+		"go.mod":             "module github.com/foo/bar\n\ngo 1.23\n",
+		"service/service.go": "package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n",
+		"wire/wire.go":       "//go:build wireinject\n\npackage wire\n",
+	}
+
+	mapFS := fstest.MapFS{}
+	for filePath, content := range sources {
+		mapFS[filePath] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	var zipBuffer bytes.Buffer
+	zipWriter := zip.NewWriter(&zipBuffer)
+	for filePath, content := range sources {
+		writer, err := zipWriter.Create(filePath)
+		assert.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBuffer.Bytes()), int64(zipBuffer.Len()))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		fsys fs.FS
+	}{
+		{
+			name: "fstest.MapFS",
+			fsys: mapFS,
+		},
+		{
+			name: "Zip archive",
+			fsys: zipReader,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			result, err := Generate(context.Background(), Options{FS: tc.fsys})
+
+			assert.NoError(tt, err)
+			if assert.Len(tt, result.Files, 1) {
+				assert.Equal(tt, filepath.Join("wire", "wire_set_gen.go"), result.Files[0].Path)
+				assert.Contains(tt, result.Files[0].Content, "service.NewService")
+				assert.False(tt, result.Files[0].Written)
+			}
+		})
+	}
+}
+
+func TestGenerate_overlay(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod":             {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"service/service.go": {Data: []byte("package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n")},
+		"wire/wire.go":       {Data: []byte("//go:build wireinject\n\npackage wire\n")},
+	}
+	genPath := filepath.Join("wire", "wire_set_gen.go")
+
+	// The overlay replaces a file of the fs.FS and receives the generated file
+	overlay := map[string]string{
+		"./service/service.go": "package service\n\n// @WireSet(\"Service\")\nfunc NewOtherService() *Service {\n\treturn nil\n}\n",
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS, Overlay: overlay})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 1) {
+		assert.Equal(t, genPath, result.Files[0].Path)
+		assert.Contains(t, result.Files[0].Content, "service.NewOtherService")
+		assert.True(t, result.Files[0].Written)
+		assert.Equal(t, result.Files[0].Content, overlay[genPath])
+	}
+	assert.NotContains(t, mapFS, genPath)
+	assert.Contains(t, overlay, "./service/service.go")
+
+	// A dry run renders the result without touching the overlay
+	overlay[genPath] = "package wire\n"
+
+	result, err = Generate(context.Background(), Options{FS: mapFS, Overlay: overlay, DryRun: true})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 1) {
+		assert.False(t, result.Files[0].Written)
+	}
+	assert.Equal(t, "package wire\n", overlay[genPath])
+}
+
+func TestGenerate_backend(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		config           string
+		injector         string
+		files            map[string]string
+		backend          string
+		expectedPath     string
+		expectedContents []string
+		expectedError    error
+	}{
+		{
+			name:             "Default",
+			expectedPath:     filepath.Join("wire", "wire_set_gen.go"),
+			expectedContents: []string{`"github.com/google/wire"`, "var ServiceSet = wire.NewSet("},
+		},
+		{
+			name:             "Option",
+			backend:          "fx",
+			expectedPath:     filepath.Join("wire", "fx_module_gen.go"),
+			expectedContents: []string{`"go.uber.org/fx"`, `var ServiceModule = fx.Module("Service",`, "fx.Provide(", "service.NewService,"},
+		},
+		{
+			name:             "Plain",
+			backend:          "plain",
+			expectedPath:     filepath.Join("wire", "build_gen.go"),
+			expectedContents: []string{"func Build() *service.Service {\n\tservice2 := service.NewService()\n\n\treturn service2\n}"},
+		},
+		{
+			name:             "Config",
+			config:           "backend: fx\n",
+			expectedPath:     filepath.Join("wire", "fx_module_gen.go"),
+			expectedContents: []string{`var ServiceModule = fx.Module("Service",`},
+		},
+		{
+			name:             "Option wins over the config",
+			config:           "backend: fx\n",
+			backend:          "wire",
+			expectedPath:     filepath.Join("wire", "wire_set_gen.go"),
+			expectedContents: []string{"var ServiceSet = wire.NewSet("},
+		},
+		{
+			name:             "Do",
+			backend:          "do",
+			expectedPath:     filepath.Join("wire", "do_register_gen.go"),
+			expectedContents: []string{"func RegisterServiceSet(i *do.Injector) {\n\tdo.Provide(i, func(i *do.Injector) (*service.Service, error) {\n\t\treturn service.NewService(), nil\n\t})\n}"},
+		},
+		{
+			name:             "Dig",
+			backend:          "dig",
+			expectedPath:     filepath.Join("wire", "dig_provide_gen.go"),
+			expectedContents: []string{"func ProvideServiceSet(c *dig.Container) error {\n\tif err := c.Provide(service.NewService); err != nil {"},
+		},
+		{
+			name:             "Directive wins over the config and the option",
+			config:           "backend: fx\nbackends:\n  wire: do\n",
+			injector:         "//go:build wireinject\n\npackage wire\n\n//wiresetgen:backend dig\n",
+			backend:          "plain",
+			expectedPath:     filepath.Join("wire", "dig_provide_gen.go"),
+			expectedContents: []string{"func ProvideServiceSet(c *dig.Container) error {"},
+		},
+		{
+			name:             "Location config wins over the option",
+			config:           "backends:\n  wire: do\n",
+			backend:          "plain",
+			expectedPath:     filepath.Join("wire", "do_register_gen.go"),
+			expectedContents: []string{"func RegisterServiceSet(i *do.Injector) {"},
+		},
+		{
+			name:          "Unknown directive backend",
+			injector:      "//go:build wireinject\n\npackage wire\n\n//wiresetgen:backend guice\n",
+			expectedError: ErrUnknownBackend,
+		},
+		{
+			name:          "Unknown backend",
+			backend:       "spring",
+			expectedError: ErrUnknownBackend,
+		},
+		{
+			name:          "Build declared by the package",
+			files:         map[string]string{"wire/build.go": "package wire\n\nfunc Build() {}\n"},
+			backend:       "plain",
+			expectedError: ErrDeclaredName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			mapFS := fstest.MapFS{
+				"go.mod":             {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+				"service/service.go": {Data: []byte("package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n")},
+				"wire/wire.go":       {Data: []byte("//go:build wireinject\n\npackage wire\n")},
+			}
+			if tc.config != "" {
+				mapFS[".wiresetgen.yaml"] = &fstest.MapFile{Data: []byte(tc.config)}
+			}
+			if tc.injector != "" {
+				mapFS["wire/wire.go"] = &fstest.MapFile{Data: []byte(tc.injector)}
+			}
+			for filePath, content := range tc.files {
+				mapFS[filePath] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			result, err := Generate(context.Background(), Options{FS: mapFS, Backend: tc.backend})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(tt, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(tt, err)
+			if assert.Len(tt, result.Files, 1) {
+				assert.Equal(tt, tc.expectedPath, result.Files[0].Path)
+				for _, expectedContent := range tc.expectedContents {
+					assert.Contains(tt, result.Files[0].Content, expectedContent)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_template(t *testing.T) {
+	t.Parallel()
+
+	const providersTemplate = `// Code generated by go-wireset-gen. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+{{- range .Imports }}
+	{{.Alias}} "{{.Path}}"
+{{- end }}
+)
+
+{{ range .WireSets -}}
+// {{ snake .SetName }} providers
+var {{ camel .VarName }} = []any{
+{{- range sortProviders .Providers }}
+	// {{.Function}} at {{.FilePath}}:{{.Line}}, {{.Doc}}
+	{{.FuncPath}},
+{{- end }}
+}
+{{ end }}`
+
+	testCases := []struct {
+		name             string
+		config           string
+		template         string
+		files            map[string]string
+		expectedContents []string
+		expectedError    error
+	}{
+		{
+			name:     "Option",
+			template: providersTemplate,
+			expectedContents: []string{
+				"// user_service providers\nvar userServiceSet = []any{",
+				"// NewUserService at " + filepath.Join("service", "service.go") + ":5, NewUserService builds the service.\n\tservice.NewUserService,",
+			},
+		},
+		{
+			name:             "Config",
+			config:           "template: custom.tmpl\n",
+			expectedContents: []string{"var userServiceSet = []any{"},
+		},
+		{
+			// The alias of a package named after its directory is dropped, so the output imports it without one
+			name:     "Package name differs from the directory",
+			template: providersTemplate,
+			files: map[string]string{
+				"transport/http/server.go": "package httpapi\n\n// @WireSet(\"UserService\")\nfunc NewServer() *Server {\n\treturn nil\n}\n",
+			},
+			expectedContents: []string{"\thttpapi.NewServer,"},
+		},
+		{
+			name:             "Variable of another file of the package",
+			template:         "package {{.PackageName}}\n\nvar name = settings.Name\n",
+			files:            map[string]string{"wire/settings.go": "package wire\n\nvar settings struct{ Name string }\n"},
+			expectedContents: []string{"var name = settings.Name\n"},
+		},
+		{
+			name:          "Invalid template",
+			template:      "{{ .PackageName ",
+			expectedError: ErrInvalidTemplate,
+		},
+		{
+			name:          "Invalid output",
+			template:      "package {{.PackageName}}\n\nvar x = fmt.Sprint()\n",
+			expectedError: ErrInvalidTemplateOutput,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			mapFS := fstest.MapFS{
+				"go.mod":             {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+				"service/service.go": {Data: []byte("package service\n\n// NewUserService builds the service.\n// @WireSet(\"UserService\")\nfunc NewUserService() *Service {\n\treturn nil\n}\n")},
+				"wire/wire.go":       {Data: []byte("//go:build wireinject\n\npackage wire\n")},
+				"custom.tmpl":        {Data: []byte(providersTemplate)},
+			}
+			templatePath := ""
+			if tc.template != "" {
+				templatePath = "option.tmpl"
+				mapFS[templatePath] = &fstest.MapFile{Data: []byte(tc.template)}
+			}
+			if tc.config != "" {
+				mapFS[".wiresetgen.yaml"] = &fstest.MapFile{Data: []byte(tc.config)}
+			}
+			for filePath, content := range tc.files {
+				mapFS[filePath] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			result, err := Generate(context.Background(), Options{FS: mapFS, Template: templatePath})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(tt, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(tt, err)
+			if assert.Len(tt, result.Files, 1) {
+				assert.Equal(tt, filepath.Join("wire", "wire_set_gen.go"), result.Files[0].Path)
+				for _, expectedContent := range tc.expectedContents {
+					assert.Contains(tt, result.Files[0].Content, expectedContent)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_naming(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		config           string
+		backend          string
+		annotations      []string
+		expectedContents []string
+		expectedError    error
+	}{
+		{
+			name:             "Suffix is not added twice",
+			annotations:      []string{"ServiceSet"},
+			expectedContents: []string{"var ServiceSet = wire.NewSet("},
+		},
+		{
+			name:             "Prefix and unexported",
+			config:           "naming:\n  strategy: prefix\n  affix: Provide\n  export: unexported\n",
+			annotations:      []string{"Service"},
+			expectedContents: []string{"var provideService = wire.NewSet("},
+		},
+		{
+			name:             "Backend affix",
+			backend:          "fx",
+			annotations:      []string{"Service"},
+			expectedContents: []string{`var ServiceModule = fx.Module("Service",`},
+		},
+		{
+			name:             "Config affix wins over the backend",
+			config:           "naming:\n  affix: Providers\n",
+			backend:          "fx",
+			annotations:      []string{"Service"},
+			expectedContents: []string{`var ServiceProviders = fx.Module("Service",`},
+		},
+		{
+			name:          "Same variable",
+			annotations:   []string{"Service", "ServiceSet"},
+			expectedError: ErrDuplicateSetName,
+		},
+		{
+			name:          "Exact keyword",
+			config:        "naming:\n  strategy: exact\n",
+			annotations:   []string{"Service", "var"},
+			expectedError: ErrInvalidSetName,
+		},
+		{
+			name:          "Invalid set name",
+			annotations:   []string{"my-set"},
+			expectedError: ErrInvalidSetName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			service := "package service\n"
+			for i, annotation := range tc.annotations {
+				service += fmt.Sprintf("\n// @WireSet(%q)\nfunc NewService%d() *Service {\n\treturn nil\n}\n", annotation, i)
+			}
+
+			mapFS := fstest.MapFS{
+				"go.mod":             {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+				"service/service.go": {Data: []byte(service)},
+				"wire/wire.go":       {Data: []byte("//go:build wireinject\n\npackage wire\n")},
+			}
+			if tc.config != "" {
+				mapFS[".wiresetgen.yaml"] = &fstest.MapFile{Data: []byte(tc.config)}
+			}
+
+			result, err := Generate(context.Background(), Options{FS: mapFS, Backend: tc.backend})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(tt, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(tt, err)
+			if assert.Len(tt, result.Files, 1) {
+				for _, expectedContent := range tc.expectedContents {
+					assert.Contains(tt, result.Files[0].Content, expectedContent)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_importAliases(t *testing.T) {
+	t.Parallel()
+
+	provider := func(packageName string, setName string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(fmt.Sprintf("package %s\n\n// @WireSet(%q)\nfunc New%s() *%s {\n\treturn nil\n}\n", packageName, setName, setName, setName))}
+	}
+
+	mapFS := fstest.MapFS{
+		"go.mod":                {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"a/service/service.go":  provider("service", "Account"),
+		"b/service/service.go":  provider("service", "Billing"),
+		"internal/wire/wire.go": provider("wire", "Options"),
+		"go-cache/cache.go":     provider("cache", "Cache"),
+		"repo/repo.go":          provider("repo", "Repository"),
+		"injector/wire.go":      {Data: []byte("//go:build wireinject\n\npackage injector\n\nvar service = 1\n")},
+		"injector/injector.go":  {Data: []byte("package injector\n")},
+		"other/wire.go":         {Data: []byte("//go:build wireinject\n\npackage other\n")},
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 2) {
+		assert.Contains(t, result.Files[0].Content, "import (\n"+
+			"    service2 \"github.com/foo/bar/a/service\"\n"+
+			"    service3 \"github.com/foo/bar/b/service\"\n"+
+			"    \"github.com/foo/bar/go-cache\"\n"+
+			"    wire2 \"github.com/foo/bar/internal/wire\"\n"+
+			"    \"github.com/foo/bar/repo\"\n")
+		assert.Contains(t, result.Files[0].Content, "var CacheSet = wire.NewSet(\n    cache.NewCache,")
+
+		// The declarations of one injector do not change the aliases of another
+		assert.Contains(t, result.Files[1].Content, "    \"github.com/foo/bar/a/service\"\n"+
+			"    service2 \"github.com/foo/bar/b/service\"\n")
+	}
+}
+
+func TestGenerate_localPackage(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod":             {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"service/service.go": {Data: []byte("package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n")},
+		"cmd/app/app.go":     {Data: []byte("package main\n\nvar service = 1\n\n// @WireSet(\"App\")\nfunc NewApp(s *service.Service) *App {\n\treturn nil\n}\n")},
+		"cmd/app/wire.go":    {Data: []byte("//go:build wireinject\n\npackage main\n")},
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 1) {
+		assert.Contains(t, result.Files[0].Content, "import (\n"+
+			"    service2 \"github.com/foo/bar/service\"\n"+
+			"    \n"+
+			"    \"github.com/google/wire\"\n)")
+		assert.Contains(t, result.Files[0].Content, "var AppSet = wire.NewSet(\n    NewApp,")
+		assert.Contains(t, result.Files[0].Content, "    service2.NewService,")
+	}
+
+	// Another location would import the main package
+	mapFS["cmd/worker/wire.go"] = &fstest.MapFile{Data: []byte("//go:build wireinject\n\npackage main\n")}
+
+	_, err = Generate(context.Background(), Options{FS: mapFS})
+
+	assert.ErrorIs(t, err, ErrUnimportablePackage)
+	assert.ErrorContains(t, err, "cmd/worker")
+}
+
+func TestGenerate_internalPackages(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod":                              {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"billing/internal/store/store.go":     {Data: []byte("package store\n\n// @WireSet(\"Store\")\nfunc NewStore() *Store {\n\treturn nil\n}\n")},
+		"billing/service.go":                  {Data: []byte("package billing\n\n// @WireSet(\"Billing\")\nfunc NewBilling() *Billing {\n\treturn nil\n}\n")},
+		"billing/cmd/worker/wire.go":          {Data: []byte("//go:build wireinject\n\npackage main\n")},
+		"cmd/api/wire.go":                     {Data: []byte("//go:build wireinject\n\npackage main\n")},
+		"internal/config/config.go":           {Data: []byte("package config\n\n// @WireSet(\"Config\")\nfunc NewConfig() *Config {\n\treturn nil\n}\n")},
+		"internal/config/internal/env/env.go": {Data: []byte("package env\n\n// @WireSet(\"Config\")\nfunc NewEnv() *Env {\n\treturn nil\n}\n")},
+	}
+
+	_, err := Generate(context.Background(), Options{FS: mapFS})
+
+	// The config set is only in part visible from both locations
+	assert.ErrorIs(t, err, ErrInternalPackage)
+
+	delete(mapFS, "internal/config/internal/env/env.go")
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 2) {
+		assert.Equal(t, "cmd/api/wire_set_gen.go", result.Files[0].Path)
+		assert.NotContains(t, result.Files[0].Content, "StoreSet")
+		assert.NotContains(t, result.Files[0].Content, "internal/store")
+		assert.Contains(t, result.Files[0].Content, "var ConfigSet")
+		assert.Equal(t, []string{
+			"set Store: store.NewStore uses github.com/foo/bar/billing/internal/store, that is internal to github.com/foo/bar/billing",
+		}, result.Files[0].Skipped)
+
+		assert.Equal(t, "billing/cmd/worker/wire_set_gen.go", result.Files[1].Path)
+		assert.Contains(t, result.Files[1].Content, "var StoreSet")
+		assert.Empty(t, result.Files[1].Skipped)
+	}
+}
+
+func TestGenerate_profiles(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod":           {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"config/config.go": {Data: []byte("package config\n\n// @WireSet(\"Config\")\nfunc NewConfig() *Config {\n\treturn nil\n}\n")},
+		"cache/cache.go": {Data: []byte("package cache\n\n" +
+			"// @WireSet(\"Cache\", profile=\"dev,test\")\nfunc NewMemoryCache() *Cache {\n\treturn nil\n}\n\n" +
+			"// @WireSet(\"Cache\", profile=\"prod\")\nfunc NewRedisCache() *Cache {\n\treturn nil\n}\n")},
+		"cmd/api/wire.go":    {Data: []byte("//go:build wireinject\n\n//wiresetgen:profile dev prod\npackage main\n")},
+		"cmd/worker/wire.go": {Data: []byte("//go:build wireinject\n\npackage main\n")},
+		".wiresetgen.yaml":   {Data: []byte("profiles:\n  cmd/worker: [test]\n")},
+		"cmd/plain/wire.go":  {Data: []byte("//go:build wireinject\n\npackage main\n")},
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 4) {
+		assert.Equal(t, "cmd/api/wire_set_gen.dev.go", result.Files[0].Path)
+		assert.Contains(t, result.Files[0].Content, "DO NOT EDIT.\n\n//go:build wireinject_dev\n\npackage main\n")
+		assert.Contains(t, result.Files[0].Content, "cache.NewMemoryCache,")
+		assert.NotContains(t, result.Files[0].Content, "cache.NewRedisCache")
+
+		assert.Equal(t, "cmd/api/wire_set_gen.prod.go", result.Files[1].Path)
+		assert.Contains(t, result.Files[1].Content, "//go:build wireinject_prod\n")
+		assert.Contains(t, result.Files[1].Content, "cache.NewRedisCache,")
+		assert.Contains(t, result.Files[1].Content, "config.NewConfig,")
+
+		// A location without profiles only gets the providers that have none
+		assert.Equal(t, "cmd/plain/wire_set_gen.go", result.Files[2].Path)
+		assert.NotContains(t, result.Files[2].Content, "CacheSet")
+
+		// A single profile needs no tag
+		assert.Equal(t, "cmd/worker/wire_set_gen.go", result.Files[3].Path)
+		assert.NotContains(t, result.Files[3].Content, "//go:build")
+		assert.Contains(t, result.Files[3].Content, "cache.NewMemoryCache,")
+	}
+
+	mapFS["cmd/worker/wire.go"] = &fstest.MapFile{Data: []byte("//go:build wireinject\n\n//wiresetgen:profile dev.local\npackage main\n")}
+
+	_, err = Generate(context.Background(), Options{FS: mapFS})
+
+	assert.ErrorIs(t, err, ErrInvalidProfile)
+}
+
+func TestGenerate_constraints(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod":                 {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"config/config.go":       {Data: []byte("package config\n\n// @WireSet(\"Config\")\nfunc NewConfig() *Config {\n\treturn nil\n}\n")},
+		"cache/cache_linux.go":   {Data: []byte("package cache\n\n// @WireSet(\"Cache\")\nfunc NewCache() *Cache {\n\treturn nil\n}\n")},
+		"cache/cache_windows.go": {Data: []byte("package cache\n\n// @WireSet(\"Cache\")\nfunc NewCache() *Cache {\n\treturn nil\n}\n")},
+		"cmd/api/wire.go":        {Data: []byte("//go:build wireinject && !js\n\npackage main\n")},
+		"cmd/desktop/wire.go":    {Data: []byte("//go:build wireinject\n\npackage main\n")},
+		".wiresetgen.yaml":       {Data: []byte("constraints:\n  cmd/desktop: windows || darwin\n")},
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Files, 4) {
+		assert.Equal(t, "cmd/api/wire_set_gen.go", result.Files[0].Path)
+		assert.Contains(t, result.Files[0].Content, "DO NOT EDIT.\n\n//go:build !js\n\npackage main\n")
+		assert.Contains(t, result.Files[0].Content, "config.NewConfig,")
+		assert.NotContains(t, result.Files[0].Content, "CacheSet")
+
+		// The provider of each platform file is one provider built with either constraint
+		assert.Equal(t, "cmd/api/wire_set_gen_cache_set.go", result.Files[1].Path)
+		assert.Contains(t, result.Files[1].Content, "//go:build !js && (linux || windows)\n")
+		assert.Equal(t, 1, strings.Count(result.Files[1].Content, "cache.NewCache,"))
+
+		assert.Equal(t, "cmd/desktop/wire_set_gen.go", result.Files[2].Path)
+		assert.Contains(t, result.Files[2].Content, "//go:build windows || darwin\n")
+
+		assert.Equal(t, "cmd/desktop/wire_set_gen_cache_set.go", result.Files[3].Path)
+		assert.Contains(t, result.Files[3].Content, "//go:build (windows || darwin) && (linux || windows)\n")
+	}
+
+	mapFS[".wiresetgen.yaml"] = &fstest.MapFile{Data: []byte("constraints:\n  cmd/desktop: windows ||\n")}
+
+	_, err = Generate(context.Background(), Options{FS: mapFS})
+
+	assert.ErrorIs(t, err, ErrInvalidConstraint)
+}
+
+func TestGenerate_annotationWarnings(t *testing.T) {
+	t.Parallel()
+
+	mapFS := fstest.MapFS{
+		"go.mod": {Data: []byte("module github.com/foo/bar\n\ngo 1.23\n")},
+		"service/service.go": {Data: []byte("package service\n\n" +
+			"// @WireSet(\"Service\", scope=\"app\")\nfunc NewService() *Service {\n\treturn nil\n}\n\n" +
+			"// @WireSet(\"Service\" bind=\"io.Reader\")\nfunc NewReader() *Reader {\n\treturn nil\n}\n\n" +
+			"//wiresetgen:set Service bind=io.Reader\nfunc NewClock() *Clock {\n\treturn nil\n}\n\n" +
+			"//wiresetgen:set Service; Cache\nfunc NewCache() *Cache {\n\treturn nil\n}\n")},
+		"cmd/api/wire.go": {Data: []byte("//go:build wireinject\n\npackage main\n")},
+	}
+
+	result, err := Generate(context.Background(), Options{FS: mapFS})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"service/service.go:3:24: unknown annotation key: scope is ignored, the keys are bind, name, profile and set",
+		"service/service.go:8:23: malformed @WireSet annotation: expected , or ) after the argument, found bind, the annotation is ignored",
+		"service/service.go:18:25: malformed //wiresetgen directive: unexpected ';', the annotation is ignored",
+	}, result.Warnings)
+	if assert.Len(t, result.Files, 1) {
+		assert.Contains(t, result.Files[0].Content, "service.NewService,")
+		assert.Contains(t, result.Files[0].Content, "wire.Bind(new(io.Reader), new(*service.Clock))")
+		assert.NotContains(t, result.Files[0].Content, "service.NewReader")
+	}
+
+	mapFS["cmd/api/wire.go"] = &fstest.MapFile{Data: []byte("//go:build wireinject\n\n//wiresetgen:backend dig fx\npackage main\n")}
+
+	_, err = Generate(context.Background(), Options{FS: mapFS})
+
+	assert.ErrorIs(t, err, ErrMalformedDirective)
+	assert.ErrorContains(t, err, "cmd/api/wire.go:3:1: ")
+}
+
+func TestGenerate_staleFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name: "Profile directive added",
+			files: map[string]string{
+				"wire/wire.go":         "//go:build wireinject\n\n//wiresetgen:profile dev prod\npackage wire\n",
+				"wire/wire_set_gen.go": "package wire\n\nvar ServiceSet = 1\n",
+			},
+			expected: []string{"wire.go", "wire_set_gen.dev.go", "wire_set_gen.prod.go"},
+		},
+		{
+			name: "Backend changed by a directive",
+			files: map[string]string{
+				"wire/wire.go":         "//go:build wireinject\n\n//wiresetgen:backend fx\npackage wire\n",
+				"wire/wire_set_gen.go": "package wire\n\nvar ServiceSet = 1\n",
+			},
+			expected: []string{"wire.go", "fx_module_gen.go"},
+		},
+		{
+			name: "Set lost its constraint",
+			files: map[string]string{
+				"wire/wire.go":                     "//go:build wireinject\n\npackage wire\n",
+				"wire/wire_set_gen_service_set.go": "//go:build linux\n\npackage wire\n\nvar ServiceSet = 1\n",
+			},
+			expected: []string{"wire.go", "wire_set_gen.go"},
+		},
+		{
+			name: "Constrained set renamed",
+			files: map[string]string{
+				"service/service.go":             "//go:build linux\n\npackage service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n",
+				"wire/wire.go":                   "//go:build wireinject\n\npackage wire\n",
+				"wire/wire_set_gen_store_set.go": "//go:build linux\n\npackage wire\n\nvar StoreSet = 1\n",
+			},
+			expected: []string{"wire.go", "wire_set_gen_service_set.go"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			files := map[string]string{
+				"go.mod":             "module github.com/foo/bar\n\ngo 1.23\n",
+				"service/service.go": "package service\n\n// @WireSet(\"Service\")\nfunc NewService() *Service {\n\treturn nil\n}\n",
+				"wire/provider.go":   "package wire\n",
+			}
+			for filePath, content := range tc.files {
+				files[filePath] = content
+			}
+			root := writeModule(tt, files)
+
+			_, err := Generate(context.Background(), Options{Dir: root, NoCache: true})
+			assert.NoError(tt, err)
+
+			// The files the run no longer produces are removed, the other files of the location are kept
+			entries, err := os.ReadDir(filepath.Join(root, "wire"))
+			assert.NoError(tt, err)
+
+			fileNames := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				fileNames = append(fileNames, entry.Name())
+			}
+			assert.ElementsMatch(tt, append(tc.expected, "provider.go"), fileNames)
+		})
+	}
+}